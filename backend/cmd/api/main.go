@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/Chelaran/mayoku/internal/api"
+	"github.com/Chelaran/mayoku/internal/config"
+	"github.com/Chelaran/mayoku/internal/database"
+	"github.com/Chelaran/mayoku/internal/game"
+	"github.com/Chelaran/mayoku/internal/storage"
+	logger "github.com/Chelaran/yagalog"
+)
+
+func main() {
+	log, err := logger.NewLogger()
+	if err != nil {
+		panic(err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("Failed to load config: %v", err)
+	}
+
+	// PostgreSQL
+	db, err := database.ConnectPostgres(cfg)
+	if err != nil {
+		log.Fatal("%v", err)
+	}
+
+	if err := database.Migrate(db); err != nil {
+		log.Fatal("%v", err)
+	}
+
+	if err := database.BootstrapSuperAdmins(db, cfg.Admin.SuperAdminTgIDs); err != nil {
+		log.Fatal("%v", err)
+	}
+
+	// Redis
+	rdb, err := database.ConnectRedis(cfg)
+	if err != nil {
+		log.Fatal("%v", err)
+	}
+	defer rdb.Close()
+
+	// MinIO
+	minioClient, err := database.ConnectMinIO(cfg)
+	if err != nil {
+		log.Fatal("%v", err)
+	}
+
+	files, err := storage.New(minioClient, cfg)
+	if err != nil {
+		log.Fatal("%v", err)
+	}
+
+	limits := game.Limits{
+		MinPlayers:  cfg.Game.MinPlayers,
+		MaxPlayers:  cfg.Game.MaxPlayers,
+		MaxSpyCount: cfg.Game.MaxSpyCount,
+		AutoSpies:   cfg.Game.AutoSpies,
+	}
+	if err := limits.Validate(); err != nil {
+		log.Fatal("Invalid game config: %v", err)
+	}
+
+	hub := game.NewHub(db, rdb, limits)
+	router := api.NewRouter(cfg, db, rdb, files, hub)
+	server := api.NewServer(cfg, router, hub)
+
+	go func() {
+		if err := server.Start(); err != nil {
+			log.Fatal("%v", err)
+		}
+	}()
+
+	// Ожидаем сигнал завершения
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.App.ShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		log.Error("%v", err)
+	}
+
+	if sqlDB, err := db.DB(); err == nil {
+		sqlDB.Close()
+	}
+
+	log.Info("Server stopped")
+}
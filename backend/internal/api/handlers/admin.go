@@ -0,0 +1,287 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Chelaran/mayoku/internal/api/middleware"
+	"github.com/Chelaran/mayoku/internal/config"
+	"github.com/Chelaran/mayoku/internal/models"
+	logger "github.com/Chelaran/yagalog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AdminHandler обрабатывает модерацию колод и управление админами
+type AdminHandler struct {
+	db         *gorm.DB
+	maxPlayers int // Под этот размер комнаты проверяется число ролей при одобрении
+	log        *logger.Logger
+}
+
+// NewAdminHandler создает новый AdminHandler
+func NewAdminHandler(db *gorm.DB, cfg *config.Config) *AdminHandler {
+	log, _ := logger.NewLogger()
+	return &AdminHandler{
+		db:         db,
+		maxPlayers: cfg.Game.MaxPlayers,
+		log:        log,
+	}
+}
+
+// DecksResponse список колод для админки
+type DecksResponse struct {
+	Decks []models.Deck `json:"decks"`
+	Count int           `json:"count"`
+}
+
+// AdminsResponse список админов
+type AdminsResponse struct {
+	Admins []models.User `json:"admins"`
+	Count  int           `json:"count"`
+}
+
+// RejectRequest тело запроса отклонения колоды
+type RejectRequest struct {
+	Reason string `json:"reason"`
+}
+
+// ListPendingDecks обрабатывает GET /api/admin/decks/pending
+func (h *AdminHandler) ListPendingDecks(w http.ResponseWriter, r *http.Request) {
+	h.listDecks(w, models.DeckStatusPending)
+}
+
+// ListDecks обрабатывает GET /api/admin/decks?status=
+func (h *AdminHandler) ListDecks(w http.ResponseWriter, r *http.Request) {
+	status := models.DeckStatus(r.URL.Query().Get("status"))
+
+	switch status {
+	case "", models.DeckStatusDraft, models.DeckStatusPending, models.DeckStatusApproved, models.DeckStatusRejected:
+	default:
+		respondError(w, http.StatusBadRequest, "invalid status")
+		return
+	}
+
+	h.listDecks(w, status)
+}
+
+// ApproveDeck обрабатывает PUT /api/admin/decks/{id}/approve
+func (h *AdminHandler) ApproveDeck(w http.ResponseWriter, r *http.Request) {
+	h.reviewDeck(w, r, models.DeckStatusApproved, "")
+}
+
+// RejectDeck обрабатывает PUT /api/admin/decks/{id}/reject
+func (h *AdminHandler) RejectDeck(w http.ResponseWriter, r *http.Request) {
+	var req RejectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		respondError(w, http.StatusBadRequest, "reason is required")
+		return
+	}
+
+	h.reviewDeck(w, r, models.DeckStatusRejected, reason)
+}
+
+// ListAdmins обрабатывает GET /api/admin/users/admins (только супер-админ)
+func (h *AdminHandler) ListAdmins(w http.ResponseWriter, r *http.Request) {
+	var admins []models.User
+	if err := h.db.Where("is_admin = ? OR is_super_admin = ?", true, true).
+		Order("id ASC").
+		Find(&admins).Error; err != nil {
+		h.log.Error("Failed to list admins: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to list admins")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, AdminsResponse{
+		Admins: admins,
+		Count:  len(admins),
+	})
+}
+
+// MakeAdmin обрабатывает PUT /api/admin/users/{id}/make-admin (только супер-админ)
+func (h *AdminHandler) MakeAdmin(w http.ResponseWriter, r *http.Request) {
+	superAdmin, _ := middleware.UserFromContext(r.Context())
+
+	user, ok := h.loadUser(w, r)
+	if !ok {
+		return
+	}
+
+	if user.IsAdmin {
+		respondJSON(w, http.StatusOK, user)
+		return
+	}
+
+	if err := h.db.Model(user).Update("is_admin", true).Error; err != nil {
+		h.log.Error("Failed to grant admin to user %d: %v", user.ID, err)
+		respondError(w, http.StatusInternalServerError, "failed to update user")
+		return
+	}
+
+	h.log.Info("User %d granted admin by super-admin %d", user.ID, superAdmin.ID)
+
+	user.IsAdmin = true
+	respondJSON(w, http.StatusOK, user)
+}
+
+// RemoveAdmin обрабатывает PUT /api/admin/users/{id}/remove-admin (только супер-админ).
+// Снимает и права супер-админа, но не с самого себя и не с последнего супер-админа.
+func (h *AdminHandler) RemoveAdmin(w http.ResponseWriter, r *http.Request) {
+	superAdmin, _ := middleware.UserFromContext(r.Context())
+
+	user, ok := h.loadUser(w, r)
+	if !ok {
+		return
+	}
+
+	if user.ID == superAdmin.ID {
+		respondError(w, http.StatusForbidden, "cannot remove your own admin rights")
+		return
+	}
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		// Строки супер-админов блокируются: иначе два супер-админа, снимающие друг друга
+		// одновременно, оба пройдут проверку и не оставят ни одного
+		var superAdminIDs []uint
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Model(&models.User{}).
+			Where("is_super_admin = ?", true).
+			Pluck("id", &superAdminIDs).Error; err != nil {
+			return err
+		}
+		if len(superAdminIDs) == 1 && slices.Contains(superAdminIDs, user.ID) {
+			return errLastSuperAdmin
+		}
+
+		return tx.Model(user).Updates(map[string]interface{}{
+			"is_admin":       false,
+			"is_super_admin": false,
+		}).Error
+	})
+	if errors.Is(err, errLastSuperAdmin) {
+		respondError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		h.log.Error("Failed to revoke admin from user %d: %v", user.ID, err)
+		respondError(w, http.StatusInternalServerError, "failed to update user")
+		return
+	}
+
+	h.log.Info("User %d revoked admin by super-admin %d", user.ID, superAdmin.ID)
+
+	user.IsAdmin = false
+	user.IsSuperAdmin = false
+	respondJSON(w, http.StatusOK, user)
+}
+
+var errLastSuperAdmin = errors.New("cannot demote the last super-admin")
+
+// loadUser загружает пользователя по {id}
+func (h *AdminHandler) loadUser(w http.ResponseWriter, r *http.Request) (*models.User, bool) {
+	id, ok := uintParam(r, "id")
+	if !ok {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return nil, false
+	}
+
+	var user models.User
+	if err := h.db.First(&user, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(w, http.StatusNotFound, "user not found")
+			return nil, false
+		}
+		respondError(w, http.StatusInternalServerError, "failed to load user")
+		return nil, false
+	}
+
+	return &user, true
+}
+
+// listDecks отправляет колоды с указанным статусом (пустой - все)
+func (h *AdminHandler) listDecks(w http.ResponseWriter, status models.DeckStatus) {
+	query := h.db.Preload("Author").Preload("Locations")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var decks []models.Deck
+	if err := query.Order("updated_at ASC").Find(&decks).Error; err != nil {
+		h.log.Error("Failed to list decks for moderation: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to list decks")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, DecksResponse{
+		Decks: decks,
+		Count: len(decks),
+	})
+}
+
+// reviewDeck переводит колоду на модерации в итоговый статус и сохраняет модератора
+func (h *AdminHandler) reviewDeck(w http.ResponseWriter, r *http.Request, status models.DeckStatus, reason string) {
+	admin, _ := middleware.UserFromContext(r.Context())
+
+	id, ok := uintParam(r, "id")
+	if !ok {
+		respondError(w, http.StatusBadRequest, "invalid deck id")
+		return
+	}
+
+	var deck models.Deck
+	if err := h.db.Preload("Author").Preload("Locations").First(&deck, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(w, http.StatusNotFound, "deck not found")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "failed to load deck")
+		return
+	}
+
+	if deck.Status != models.DeckStatusPending {
+		respondError(w, http.StatusConflict, fmt.Sprintf("deck in status %q cannot be reviewed", deck.Status))
+		return
+	}
+
+	// Одобренная колода должна раздавать уникальные роли даже в полной комнате
+	if status == models.DeckStatusApproved {
+		for i := range deck.Locations {
+			if err := deck.Locations[i].ValidateForPlayers(h.maxPlayers); err != nil {
+				respondError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+	}
+
+	now := time.Now()
+	deck.Status = status
+	deck.RejectionReason = reason
+	deck.ReviewedByID = &admin.ID
+	deck.ReviewedAt = &now
+
+	if err := h.db.Model(&deck).Updates(map[string]interface{}{
+		"status":           deck.Status,
+		"rejection_reason": deck.RejectionReason,
+		"reviewed_by_id":   deck.ReviewedByID,
+		"reviewed_at":      deck.ReviewedAt,
+	}).Error; err != nil {
+		h.log.Error("Failed to review deck %d: %v", deck.ID, err)
+		respondError(w, http.StatusInternalServerError, "failed to update deck")
+		return
+	}
+
+	h.log.Info("Deck %d %s by admin %d", deck.ID, status, admin.ID)
+
+	respondJSON(w, http.StatusOK, deck)
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Chelaran/mayoku/internal/config"
+	"github.com/Chelaran/mayoku/internal/models"
+	"github.com/Chelaran/mayoku/internal/utils"
+	logger "github.com/Chelaran/yagalog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AuthHandler обрабатывает аутентификацию через Telegram
+type AuthHandler struct {
+	db  *gorm.DB
+	cfg *config.Config
+	log *logger.Logger
+}
+
+// NewAuthHandler создает новый AuthHandler
+func NewAuthHandler(db *gorm.DB, cfg *config.Config) *AuthHandler {
+	log, _ := logger.NewLogger()
+	return &AuthHandler{
+		db:  db,
+		cfg: cfg,
+		log: log,
+	}
+}
+
+// AuthRequest тело запроса POST /api/auth
+type AuthRequest struct {
+	InitData string `json:"init_data"`
+}
+
+// AuthResponse ответ с JWT токеном и пользователем
+type AuthResponse struct {
+	Token string      `json:"token"`
+	User  models.User `json:"user"`
+}
+
+// Authenticate обрабатывает POST /api/auth: проверяет initData и выдает JWT
+func (h *AuthHandler) Authenticate(w http.ResponseWriter, r *http.Request) {
+	var req AuthRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.InitData == "" {
+		respondError(w, http.StatusBadRequest, "init_data is required")
+		return
+	}
+
+	if _, err := utils.VerifyTelegramInitData(req.InitData, h.cfg.Telegram.BotToken); err != nil {
+		h.log.Warning("Invalid initData: %v", err)
+		respondError(w, http.StatusUnauthorized, "invalid init_data")
+		return
+	}
+
+	tgUser, err := utils.ParseTelegramUser(req.InitData)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user in init_data")
+		return
+	}
+
+	user, err := h.upsertUser(tgUser)
+	if err != nil {
+		h.log.Error("Failed to save user %d: %v", tgUser.ID, err)
+		respondError(w, http.StatusInternalServerError, "failed to save user")
+		return
+	}
+
+	token, err := utils.GenerateJWT(user.ID, user.TgID, h.cfg.JWT.Secret)
+	if err != nil {
+		h.log.Error("Failed to generate JWT: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to generate token")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, AuthResponse{
+		Token: token,
+		User:  *user,
+	})
+}
+
+// upsertUser создает пользователя по TgID или обновляет его имя и аватар.
+// Пользователи из SUPER_ADMIN_TG_IDS получают права супер-админа.
+func (h *AuthHandler) upsertUser(tgUser *utils.TelegramUser) (*models.User, error) {
+	username := telegramDisplayName(tgUser)
+
+	isSuperAdmin := h.cfg.IsSuperAdminTgID(tgUser.ID)
+
+	var user models.User
+	err := h.db.Where("tg_id = ?", tgUser.ID).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		user = models.User{
+			TgID:         tgUser.ID,
+			Username:     username,
+			AvatarURL:    tgUser.PhotoURL,
+			IsAdmin:      isSuperAdmin,
+			IsSuperAdmin: isSuperAdmin,
+		}
+		// Параллельный вход того же нового пользователя (перезагрузка Mini App) мог уже создать запись -
+		// тогда читаем ее и обновляем как обычно
+		result := h.db.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "tg_id"}},
+			DoNothing: true,
+		}).Create(&user)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected > 0 {
+			return &user, nil
+		}
+
+		user = models.User{}
+		err = h.db.Where("tg_id = ?", tgUser.ID).First(&user).Error
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	updates := map[string]interface{}{}
+	if user.Username != username || user.AvatarURL != tgUser.PhotoURL {
+		user.Username = username
+		user.AvatarURL = tgUser.PhotoURL
+		updates["username"] = user.Username
+		updates["avatar_url"] = user.AvatarURL
+	}
+	if isSuperAdmin && !user.IsSuperAdmin {
+		user.IsAdmin = true
+		user.IsSuperAdmin = true
+		updates["is_admin"] = true
+		updates["is_super_admin"] = true
+	}
+
+	if len(updates) > 0 {
+		if err := h.db.Model(&user).Updates(updates).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return &user, nil
+}
+
+// telegramDisplayName возвращает username, а если его нет - имя и фамилию
+func telegramDisplayName(tgUser *utils.TelegramUser) string {
+	if tgUser.Username != "" {
+		return tgUser.Username
+	}
+
+	return strings.TrimSpace(tgUser.FirstName + " " + tgUser.LastName)
+}
@@ -0,0 +1,500 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Chelaran/mayoku/internal/api/middleware"
+	"github.com/Chelaran/mayoku/internal/config"
+	"github.com/Chelaran/mayoku/internal/models"
+	"github.com/Chelaran/mayoku/internal/storage"
+	logger "github.com/Chelaran/yagalog"
+	"gorm.io/gorm"
+)
+
+// DeckHandler обрабатывает CRUD колод и их локаций
+type DeckHandler struct {
+	db         *gorm.DB
+	storage    *storage.Storage
+	maxPlayers int // Под этот размер комнаты проверяется число ролей при отправке на модерацию
+	log        *logger.Logger
+}
+
+// NewDeckHandler создает новый DeckHandler
+func NewDeckHandler(db *gorm.DB, storage *storage.Storage, cfg *config.Config) *DeckHandler {
+	log, _ := logger.NewLogger()
+	return &DeckHandler{
+		db:         db,
+		storage:    storage,
+		maxPlayers: cfg.Game.MaxPlayers,
+		log:        log,
+	}
+}
+
+// LocationRequest локация в теле запроса. ImageURL - ссылка на карточку из /api/upload,
+// миниатюра к ней определяется сервером
+type LocationRequest struct {
+	Name         string   `json:"name"`
+	ImageURL     string   `json:"image_url"`
+	Roles        []string `json:"roles"`
+	FallbackRole string   `json:"fallback_role"`
+}
+
+// DeckRequest тело запроса создания/обновления колоды
+type DeckRequest struct {
+	Name         string            `json:"name"`
+	IsPublic     bool              `json:"is_public"`
+	FallbackRole string            `json:"fallback_role"`
+	Locations    []LocationRequest `json:"locations"`
+}
+
+// List обрабатывает GET /api/decks: одобренные колоды
+func (h *DeckHandler) List(w http.ResponseWriter, r *http.Request) {
+	if status := r.URL.Query().Get("status"); status != "" && models.DeckStatus(status) != models.DeckStatusApproved {
+		respondError(w, http.StatusBadRequest, "only approved decks are listed publicly")
+		return
+	}
+
+	var decks []models.Deck
+	if err := h.db.Preload("Author").Preload("Locations").
+		Where("status = ?", models.DeckStatusApproved).
+		Order("created_at DESC").
+		Find(&decks).Error; err != nil {
+		h.log.Error("Failed to list decks: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to list decks")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, decks)
+}
+
+// ListMine обрабатывает GET /api/decks/my: колоды текущего пользователя
+func (h *DeckHandler) ListMine(w http.ResponseWriter, r *http.Request) {
+	user, _ := middleware.UserFromContext(r.Context())
+
+	query := h.db.Preload("Locations").Where("author_id = ?", user.ID)
+	if status := r.URL.Query().Get("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var decks []models.Deck
+	if err := query.Order("updated_at DESC").Find(&decks).Error; err != nil {
+		h.log.Error("Failed to list decks of user %d: %v", user.ID, err)
+		respondError(w, http.StatusInternalServerError, "failed to list decks")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, decks)
+}
+
+// Get обрабатывает GET /api/decks/{id}
+func (h *DeckHandler) Get(w http.ResponseWriter, r *http.Request) {
+	user, _ := middleware.UserFromContext(r.Context())
+
+	deck, ok := h.loadDeck(w, r, true)
+	if !ok {
+		return
+	}
+
+	// Неодобренные колоды видят только автор и админы
+	if deck.Status != models.DeckStatusApproved && deck.AuthorID != user.ID && !user.IsAdmin && !user.IsSuperAdmin {
+		respondError(w, http.StatusNotFound, "deck not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, deck)
+}
+
+// Create обрабатывает POST /api/decks: создает черновик
+func (h *DeckHandler) Create(w http.ResponseWriter, r *http.Request) {
+	user, _ := middleware.UserFromContext(r.Context())
+
+	var req DeckRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	locations, err := h.buildLocations(req.Locations)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	deck := models.Deck{
+		AuthorID:     user.ID,
+		Name:         strings.TrimSpace(req.Name),
+		IsPublic:     req.IsPublic,
+		FallbackRole: strings.TrimSpace(req.FallbackRole),
+		Status:       models.DeckStatusDraft,
+		Locations:    locations,
+	}
+
+	if deck.Name == "" {
+		respondError(w, http.StatusBadRequest, "deck name is required")
+		return
+	}
+
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&deck).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.User{}).Where("id = ?", user.ID).
+			UpdateColumn("decks_created", gorm.Expr("decks_created + ?", 1)).Error
+	})
+	if err != nil {
+		h.log.Error("Failed to create deck: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to create deck")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, deck)
+}
+
+// Update обрабатывает PUT /api/decks/{id}: полностью заменяет название и локации
+func (h *DeckHandler) Update(w http.ResponseWriter, r *http.Request) {
+	deck, ok := h.loadEditableDeck(w, r)
+	if !ok {
+		return
+	}
+
+	var req DeckRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		respondError(w, http.StatusBadRequest, "deck name is required")
+		return
+	}
+
+	fallbackRole := strings.TrimSpace(req.FallbackRole)
+
+	locations, err := h.buildLocations(req.Locations)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		// Отредактированная колода снова становится черновиком
+		if err := tx.Model(deck).Updates(map[string]interface{}{
+			"name":          name,
+			"is_public":     req.IsPublic,
+			"fallback_role": fallbackRole,
+			"status":        models.DeckStatusDraft,
+		}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("deck_id = ?", deck.ID).Delete(&models.Location{}).Error; err != nil {
+			return err
+		}
+
+		for i := range locations {
+			locations[i].DeckID = deck.ID
+		}
+		if len(locations) > 0 {
+			return tx.Create(&locations).Error
+		}
+
+		return nil
+	})
+	if err != nil {
+		h.log.Error("Failed to update deck %d: %v", deck.ID, err)
+		respondError(w, http.StatusInternalServerError, "failed to update deck")
+		return
+	}
+
+	deck.Name = name
+	deck.IsPublic = req.IsPublic
+	deck.FallbackRole = fallbackRole
+	deck.Status = models.DeckStatusDraft
+	deck.Locations = locations
+	respondJSON(w, http.StatusOK, deck)
+}
+
+// Delete обрабатывает DELETE /api/decks/{id} (только автор)
+func (h *DeckHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	user, _ := middleware.UserFromContext(r.Context())
+
+	deck, ok := h.loadDeck(w, r, false)
+	if !ok {
+		return
+	}
+
+	if deck.AuthorID != user.ID {
+		respondError(w, http.StatusForbidden, "only the author can delete the deck")
+		return
+	}
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("deck_id = ?", deck.ID).Delete(&models.Location{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(deck).Error
+	})
+	if err != nil {
+		h.log.Error("Failed to delete deck %d: %v", deck.ID, err)
+		respondError(w, http.StatusInternalServerError, "failed to delete deck")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Submit обрабатывает POST /api/decks/{id}/submit: отправка на модерацию
+func (h *DeckHandler) Submit(w http.ResponseWriter, r *http.Request) {
+	deck, ok := h.loadEditableDeck(w, r)
+	if !ok {
+		return
+	}
+
+	if len(deck.Locations) < models.MinDeckLocations {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("deck must have at least %d location(s)", models.MinDeckLocations))
+		return
+	}
+
+	for i := range deck.Locations {
+		if err := deck.Locations[i].Validate(); err != nil {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Та же проверка, что и при одобрении: автор узнает о нехватке ролей сразу
+		if err := deck.Locations[i].ValidateForPlayers(h.maxPlayers); err != nil {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	if err := h.db.Model(deck).Update("status", models.DeckStatusPending).Error; err != nil {
+		h.log.Error("Failed to submit deck %d: %v", deck.ID, err)
+		respondError(w, http.StatusInternalServerError, "failed to submit deck")
+		return
+	}
+
+	deck.Status = models.DeckStatusPending
+	respondJSON(w, http.StatusOK, deck)
+}
+
+// AddLocation обрабатывает POST /api/decks/{id}/locations
+func (h *DeckHandler) AddLocation(w http.ResponseWriter, r *http.Request) {
+	deck, ok := h.loadEditableDeck(w, r)
+	if !ok {
+		return
+	}
+
+	location, ok := h.decodeLocation(w, r)
+	if !ok {
+		return
+	}
+	location.DeckID = deck.ID
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(location).Error; err != nil {
+			return err
+		}
+		return tx.Model(deck).Update("status", models.DeckStatusDraft).Error
+	})
+	if err != nil {
+		h.log.Error("Failed to add location to deck %d: %v", deck.ID, err)
+		respondError(w, http.StatusInternalServerError, "failed to add location")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, location)
+}
+
+// UpdateLocation обрабатывает PUT /api/decks/{id}/locations/{locationId}
+func (h *DeckHandler) UpdateLocation(w http.ResponseWriter, r *http.Request) {
+	deck, ok := h.loadEditableDeck(w, r)
+	if !ok {
+		return
+	}
+
+	existing, ok := findLocation(w, r, deck)
+	if !ok {
+		return
+	}
+
+	location, ok := h.decodeLocation(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(existing).Updates(map[string]interface{}{
+			"name":          location.Name,
+			"image_url":     location.ImageURL,
+			"thumbnail_url": location.ThumbnailURL,
+			"roles":         location.Roles,
+			"fallback_role": location.FallbackRole,
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Model(deck).Update("status", models.DeckStatusDraft).Error
+	})
+	if err != nil {
+		h.log.Error("Failed to update location %d: %v", existing.ID, err)
+		respondError(w, http.StatusInternalServerError, "failed to update location")
+		return
+	}
+
+	existing.Name = location.Name
+	existing.ImageURL = location.ImageURL
+	existing.ThumbnailURL = location.ThumbnailURL
+	existing.Roles = location.Roles
+	existing.FallbackRole = location.FallbackRole
+	respondJSON(w, http.StatusOK, existing)
+}
+
+// DeleteLocation обрабатывает DELETE /api/decks/{id}/locations/{locationId}
+func (h *DeckHandler) DeleteLocation(w http.ResponseWriter, r *http.Request) {
+	deck, ok := h.loadEditableDeck(w, r)
+	if !ok {
+		return
+	}
+
+	location, ok := findLocation(w, r, deck)
+	if !ok {
+		return
+	}
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(location).Error; err != nil {
+			return err
+		}
+		return tx.Model(deck).Update("status", models.DeckStatusDraft).Error
+	})
+	if err != nil {
+		h.log.Error("Failed to delete location %d: %v", location.ID, err)
+		respondError(w, http.StatusInternalServerError, "failed to delete location")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// loadDeck загружает колоду по {id}, при необходимости с локациями
+func (h *DeckHandler) loadDeck(w http.ResponseWriter, r *http.Request, withAuthor bool) (*models.Deck, bool) {
+	id, ok := uintParam(r, "id")
+	if !ok {
+		respondError(w, http.StatusBadRequest, "invalid deck id")
+		return nil, false
+	}
+
+	query := h.db.Preload("Locations")
+	if withAuthor {
+		query = query.Preload("Author")
+	}
+
+	var deck models.Deck
+	if err := query.First(&deck, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(w, http.StatusNotFound, "deck not found")
+			return nil, false
+		}
+		h.log.Error("Failed to load deck %d: %v", id, err)
+		respondError(w, http.StatusInternalServerError, "failed to load deck")
+		return nil, false
+	}
+
+	return &deck, true
+}
+
+// loadEditableDeck загружает колоду, которую текущий пользователь может редактировать
+func (h *DeckHandler) loadEditableDeck(w http.ResponseWriter, r *http.Request) (*models.Deck, bool) {
+	user, _ := middleware.UserFromContext(r.Context())
+
+	deck, ok := h.loadDeck(w, r, false)
+	if !ok {
+		return nil, false
+	}
+
+	if deck.AuthorID != user.ID {
+		respondError(w, http.StatusForbidden, "only the author can edit the deck")
+		return nil, false
+	}
+
+	if !deck.IsEditable() {
+		respondError(w, http.StatusConflict, fmt.Sprintf("deck in status %q cannot be edited", deck.Status))
+		return nil, false
+	}
+
+	return deck, true
+}
+
+// findLocation ищет локацию {locationId} среди локаций колоды
+func findLocation(w http.ResponseWriter, r *http.Request, deck *models.Deck) (*models.Location, bool) {
+	locationID, ok := uintParam(r, "locationId")
+	if !ok {
+		respondError(w, http.StatusBadRequest, "invalid location id")
+		return nil, false
+	}
+
+	for i := range deck.Locations {
+		if deck.Locations[i].ID == locationID {
+			return &deck.Locations[i], true
+		}
+	}
+
+	respondError(w, http.StatusNotFound, "location not found")
+	return nil, false
+}
+
+// decodeLocation читает и валидирует локацию из тела запроса
+func (h *DeckHandler) decodeLocation(w http.ResponseWriter, r *http.Request) (*models.Location, bool) {
+	var req LocationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return nil, false
+	}
+
+	locations, err := h.buildLocations([]LocationRequest{req})
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+
+	return &locations[0], true
+}
+
+// buildLocations преобразует запрос в модели, проверяет количество ролей и картинку
+func (h *DeckHandler) buildLocations(reqs []LocationRequest) ([]models.Location, error) {
+	locations := make([]models.Location, 0, len(reqs))
+	for _, req := range reqs {
+		location := models.Location{
+			Name:         req.Name,
+			ImageURL:     req.ImageURL,
+			Roles:        models.StringArray(req.Roles),
+			FallbackRole: req.FallbackRole,
+		}
+		location.Normalize()
+
+		if err := location.Validate(); err != nil {
+			return nil, err
+		}
+
+		// Принимаются только карточки, обработанные нашей загрузкой
+		if location.ImageURL != "" {
+			key, ok := h.storage.CardKeyFromURL(location.ImageURL)
+			if !ok {
+				return nil, fmt.Errorf("location %q: image_url must be an uploaded image", location.Name)
+			}
+
+			_, thumbnailKey := storage.ImageKeys(key)
+			location.ThumbnailURL = h.storage.PublicURL(thumbnailKey)
+		}
+
+		locations = append(locations, location)
+	}
+
+	return locations, nil
+}
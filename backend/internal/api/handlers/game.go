@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/Chelaran/mayoku/internal/api/middleware"
+	"github.com/Chelaran/mayoku/internal/config"
+	"github.com/Chelaran/mayoku/internal/game"
+	"github.com/Chelaran/mayoku/internal/models"
+	logger "github.com/Chelaran/yagalog"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"gorm.io/gorm"
+)
+
+// GameHandler обрабатывает игровые запросы и WebSocket подключения
+type GameHandler struct {
+	hub            *game.Hub
+	db             *gorm.DB
+	upgrader       websocket.Upgrader
+	allowedOrigins map[string]bool
+	log            *logger.Logger
+}
+
+// NewGameHandler создает новый GameHandler
+func NewGameHandler(hub *game.Hub, db *gorm.DB, cfg *config.Config) *GameHandler {
+	log, _ := logger.NewLogger()
+
+	allowed := make(map[string]bool)
+	for _, origin := range cfg.App.AllowedOrigins {
+		allowed[strings.TrimSuffix(origin, "/")] = true
+	}
+	for _, origin := range cfg.App.WSAllowedOrigins {
+		allowed[strings.TrimSuffix(origin, "/")] = true
+	}
+
+	h := &GameHandler{
+		hub:            hub,
+		db:             db,
+		allowedOrigins: allowed,
+		log:            log,
+	}
+
+	h.upgrader = websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		Subprotocols:    []string{middleware.WSTokenProtocol},
+		CheckOrigin:     h.checkOrigin,
+	}
+
+	return h
+}
+
+// ServeWS обрабатывает GET /api/game/ws: upgrade до WebSocket и запуск клиента
+func (h *GameHandler) ServeWS(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrader уже отправил ответ с ошибкой
+		h.log.Warning("WebSocket upgrade failed for user %d: %v", user.ID, err)
+		return
+	}
+
+	client := game.NewClient(conn, h.hub, user.ID, user.TgID, user.Username, user.AvatarURL)
+	h.hub.Register(client)
+
+	go client.WritePump()
+	go client.ReadPump()
+}
+
+// CreateRoomRequest тело запроса POST /api/game/rooms
+type CreateRoomRequest struct {
+	DeckID uint `json:"deck_id"`
+	game.SettingsUpdate
+}
+
+// CreateRoom обрабатывает POST /api/game/rooms
+func (h *GameHandler) CreateRoom(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req CreateRoomRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.DeckID == 0 {
+		respondError(w, http.StatusBadRequest, "deck_id is required")
+		return
+	}
+
+	// Колода проверяется ниже
+	req.SettingsUpdate.DeckID = nil
+
+	limits := h.hub.Limits()
+	if err := limits.ValidateSettings(req.SettingsUpdate); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Играть можно одобренной колодой или своей собственной
+	var deck models.Deck
+	if err := h.db.First(&deck, req.DeckID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(w, http.StatusNotFound, "deck not found")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "failed to load deck")
+		return
+	}
+
+	if !deck.IsAvailableTo(user.ID) {
+		respondError(w, http.StatusForbidden, "deck is not available")
+		return
+	}
+
+	maxPlayers := intOrDefault(req.MaxPlayers, limits.DefaultMaxPlayers())
+	spyCount := intOrDefault(req.SpyCount, game.DefaultSpyCount)
+	duration := intOrDefault(req.Duration, game.DefaultDuration)
+
+	roomID := uuid.NewString()
+	room, err := h.hub.CreateRoom(roomID, user.ID, deck.ID, deck.Name, maxPlayers, spyCount, duration)
+	if err != nil {
+		h.log.Error("Failed to create room: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to create room")
+		return
+	}
+
+	// Остальные настройки (режим таймера и т.д.)
+	if err := room.UpdateSettings(user.ID, req.SettingsUpdate); err != nil {
+		h.hub.DeleteRoom(roomID)
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, map[string]string{
+		"room_id": roomID,
+	})
+}
+
+// ListRooms обрабатывает GET /api/game/rooms: только открытые комнаты
+func (h *GameHandler) ListRooms(w http.ResponseWriter, r *http.Request) {
+	rooms := make([]game.RoomSummary, 0)
+	for _, room := range h.hub.ListRooms() {
+		if room.Status == game.StatusWaiting && !room.InMatch && room.PlayersCount < room.MaxPlayers {
+			rooms = append(rooms, room)
+		}
+	}
+
+	// Новые комнаты первыми
+	sort.Slice(rooms, func(i, j int) bool {
+		return rooms[i].CreatedAt.After(rooms[j].CreatedAt)
+	})
+
+	respondJSON(w, http.StatusOK, rooms)
+}
+
+// intOrDefault возвращает значение указателя или значение по умолчанию
+func intOrDefault(value *int, def int) int {
+	if value == nil {
+		return def
+	}
+	return *value
+}
+
+// checkOrigin разрешает свой хост (Mini App открыт с того же домена),
+// а также источники из конфигурации
+func (h *GameHandler) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		// Не браузерный клиент
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+
+	if strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+
+	if h.allowedOrigins["*"] || h.allowedOrigins[strings.TrimSuffix(origin, "/")] {
+		return true
+	}
+
+	h.log.Warning("WebSocket origin rejected: %s", origin)
+	return false
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// HealthHandler проверяет доступность зависимостей
+type HealthHandler struct {
+	db    *gorm.DB
+	redis *redis.Client
+}
+
+// NewHealthHandler создает новый HealthHandler
+func NewHealthHandler(db *gorm.DB, redis *redis.Client) *HealthHandler {
+	return &HealthHandler{
+		db:    db,
+		redis: redis,
+	}
+}
+
+// Check обрабатывает GET /api/health
+func (h *HealthHandler) Check(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+
+	sqlDB, err := h.db.DB()
+	if err != nil || sqlDB.PingContext(ctx) != nil {
+		respondError(w, http.StatusServiceUnavailable, "postgres unavailable")
+		return
+	}
+
+	if err := h.redis.Ping(ctx).Err(); err != nil {
+		respondError(w, http.StatusServiceUnavailable, "redis unavailable")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{
+		"status": "ok",
+	})
+}
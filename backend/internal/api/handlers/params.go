@@ -0,0 +1,17 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// uintParam читает числовой параметр пути (например, {id})
+func uintParam(r *http.Request, name string) (uint, bool) {
+	value, err := strconv.ParseUint(chi.URLParam(r, name), 10, 64)
+	if err != nil || value == 0 {
+		return 0, false
+	}
+	return uint(value), true
+}
@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Chelaran/mayoku/internal/config"
+	"github.com/Chelaran/mayoku/internal/storage"
+	logger "github.com/Chelaran/yagalog"
+)
+
+// allowedImageTypes допустимые форматы картинок: content-type -> расширение
+var allowedImageTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+	"image/gif":  ".gif",
+}
+
+// UploadHandler обрабатывает загрузку картинок в MinIO
+type UploadHandler struct {
+	storage       *storage.Storage
+	maxSize       int64
+	presignExpiry time.Duration
+	log           *logger.Logger
+}
+
+// NewUploadHandler создает новый UploadHandler
+func NewUploadHandler(storage *storage.Storage, cfg *config.Config) *UploadHandler {
+	log, _ := logger.NewLogger()
+	return &UploadHandler{
+		storage:       storage,
+		maxSize:       cfg.Upload.MaxSizeMB << 20,
+		presignExpiry: cfg.Upload.PresignExpiry,
+		log:           log,
+	}
+}
+
+// UploadResponse ссылки на карточку и миниатюру загруженной картинки
+type UploadResponse struct {
+	URL          string `json:"url"`
+	Key          string `json:"key"`
+	ThumbnailURL string `json:"thumbnail_url"`
+	ThumbnailKey string `json:"thumbnail_key"`
+}
+
+// CompleteRequest тело запроса POST /api/upload/presigned/complete
+type CompleteRequest struct {
+	Key string `json:"key"`
+}
+
+// PresignRequest тело запроса POST /api/upload/presigned
+type PresignRequest struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+}
+
+// PresignResponse presigned POST: файл отправляется формой на URL с полями Fields и полем file.
+// Публичной ссылки на сырой файл нет - ее возвращает /complete после обработки
+type PresignResponse struct {
+	URL       string            `json:"url"`
+	Fields    map[string]string `json:"fields"`
+	Key       string            `json:"key"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+// Upload обрабатывает POST /api/upload (multipart/form-data, поле file).
+// Картинка перекодируется в JPEG карточки и миниатюру, EXIF удаляется.
+func (h *UploadHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	// Запас на заголовки multipart
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxSize+1<<20)
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			respondError(w, http.StatusRequestEntityTooLarge, h.tooLargeMessage())
+			return
+		}
+		respondError(w, http.StatusBadRequest, "file is required")
+		return
+	}
+	defer file.Close()
+
+	if header.Size > h.maxSize {
+		respondError(w, http.StatusRequestEntityTooLarge, h.tooLargeMessage())
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(file, h.maxSize+1))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "failed to read file")
+		return
+	}
+	if int64(len(data)) > h.maxSize {
+		respondError(w, http.StatusRequestEntityTooLarge, h.tooLargeMessage())
+		return
+	}
+
+	// Тип определяем по содержимому, а не по заголовку клиента
+	if _, ok := allowedImageTypes[http.DetectContentType(data)]; !ok {
+		respondError(w, http.StatusUnsupportedMediaType, "unsupported image format")
+		return
+	}
+
+	resp, err := h.storeImage(r.Context(), storage.NewObjectKey(".jpg"), data)
+	if err != nil {
+		h.respondImageError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, resp)
+}
+
+// Presign обрабатывает POST /api/upload/presigned: ключ объекта выбирает сервер
+func (h *UploadHandler) Presign(w http.ResponseWriter, r *http.Request) {
+	var req PresignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	contentType := strings.ToLower(strings.TrimSpace(req.ContentType))
+	ext, ok := allowedImageTypes[contentType]
+	if !ok {
+		respondError(w, http.StatusUnsupportedMediaType, "unsupported image format")
+		return
+	}
+
+	key := storage.NewUploadKey(ext)
+	url, fields, err := h.storage.PresignedPost(r.Context(), key, contentType, h.maxSize, h.presignExpiry)
+	if err != nil {
+		h.log.Error("%v", err)
+		respondError(w, http.StatusInternalServerError, "failed to generate upload url")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, PresignResponse{
+		URL:       url,
+		Fields:    fields,
+		Key:       key,
+		ExpiresAt: time.Now().Add(h.presignExpiry),
+	})
+}
+
+// CompletePresigned обрабатывает POST /api/upload/presigned/complete:
+// обрабатывает файл, загруженный по presigned URL, как обычную загрузку.
+// Сырой файл удаляется в любом случае - и после обработки, и при ошибке
+func (h *UploadHandler) CompletePresigned(w http.ResponseWriter, r *http.Request) {
+	var req CompleteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if !storage.IsUploadKey(req.Key) {
+		respondError(w, http.StatusBadRequest, "invalid key")
+		return
+	}
+
+	defer func() {
+		if err := h.storage.Remove(context.WithoutCancel(r.Context()), req.Key); err != nil {
+			h.log.Warning("%v", err)
+		}
+	}()
+
+	data, err := h.storage.Download(r.Context(), req.Key, h.maxSize)
+	if err != nil {
+		if errors.Is(err, storage.ErrTooLarge) {
+			respondError(w, http.StatusRequestEntityTooLarge, h.tooLargeMessage())
+			return
+		}
+		h.log.Warning("%v", err)
+		respondError(w, http.StatusNotFound, "uploaded file not found")
+		return
+	}
+
+	if _, ok := allowedImageTypes[http.DetectContentType(data)]; !ok {
+		respondError(w, http.StatusUnsupportedMediaType, "unsupported image format")
+		return
+	}
+
+	resp, err := h.storeImage(r.Context(), storage.NewObjectKey(".jpg"), data)
+	if err != nil {
+		h.respondImageError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// storeImage сохраняет карточку и миниатюру рядом друг с другом в bucket
+func (h *UploadHandler) storeImage(ctx context.Context, key string, data []byte) (*UploadResponse, error) {
+	processed, err := storage.ProcessImage(data)
+	if err != nil {
+		return nil, &imageError{err: err}
+	}
+
+	cardKey, thumbnailKey := storage.ImageKeys(key)
+
+	if err := h.storage.Upload(ctx, cardKey, bytes.NewReader(processed.Card), int64(len(processed.Card)), "image/jpeg"); err != nil {
+		return nil, err
+	}
+
+	if err := h.storage.Upload(ctx, thumbnailKey, bytes.NewReader(processed.Thumbnail), int64(len(processed.Thumbnail)), "image/jpeg"); err != nil {
+		return nil, err
+	}
+
+	return &UploadResponse{
+		URL:          h.storage.PublicURL(cardKey),
+		Key:          cardKey,
+		ThumbnailURL: h.storage.PublicURL(thumbnailKey),
+		ThumbnailKey: thumbnailKey,
+	}, nil
+}
+
+// imageError картинку не удалось декодировать (ошибка клиента)
+type imageError struct {
+	err error
+}
+
+func (e *imageError) Error() string {
+	return e.err.Error()
+}
+
+// respondImageError отвечает 422 на битую картинку и 500 на ошибки хранилища
+func (h *UploadHandler) respondImageError(w http.ResponseWriter, err error) {
+	var imgErr *imageError
+	if errors.As(err, &imgErr) {
+		respondError(w, http.StatusUnprocessableEntity, "failed to process image")
+		return
+	}
+
+	h.log.Error("%v", err)
+	respondError(w, http.StatusInternalServerError, "failed to upload file")
+}
+
+// tooLargeMessage сообщение о превышении размера файла
+func (h *UploadHandler) tooLargeMessage() string {
+	return fmt.Sprintf("file is too large (max %d MB)", h.maxSize>>20)
+}
@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Chelaran/mayoku/internal/api/middleware"
+)
+
+// UserHandler обрабатывает запросы профиля
+type UserHandler struct{}
+
+// NewUserHandler создает новый UserHandler
+func NewUserHandler() *UserHandler {
+	return &UserHandler{}
+}
+
+// Me обрабатывает GET /api/user/me
+func (h *UserHandler) Me(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, user)
+}
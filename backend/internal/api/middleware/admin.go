@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+)
+
+// RequireAdmin пропускает только админов и супер-админов (после Auth)
+func RequireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, ok := UserFromContext(r.Context())
+		if !ok {
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+
+		if !user.IsAdmin && !user.IsSuperAdmin {
+			writeError(w, http.StatusForbidden, "admin rights required")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireSuperAdmin пропускает только супер-админов (после Auth)
+func RequireSuperAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, ok := UserFromContext(r.Context())
+		if !ok {
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+
+		if !user.IsSuperAdmin {
+			writeError(w, http.StatusForbidden, "super-admin rights required")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Chelaran/mayoku/internal/models"
+	"github.com/Chelaran/mayoku/internal/utils"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/websocket"
+	"gorm.io/gorm"
+)
+
+type contextKey string
+
+const userContextKey contextKey = "user"
+
+// WSTokenProtocol подпротокол WebSocket, за которым следует JWT токен:
+// new WebSocket(url, ["bearer", token])
+const WSTokenProtocol = "bearer"
+
+// Auth проверяет JWT токен и кладет models.User в контекст запроса
+func Auth(db *gorm.DB, secret string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tokenString := tokenFromRequest(r)
+			if tokenString == "" {
+				writeError(w, http.StatusUnauthorized, "missing authorization token")
+				return
+			}
+
+			claims, err := utils.ValidateJWT(tokenString, secret)
+			if err != nil {
+				if errors.Is(err, jwt.ErrTokenExpired) {
+					writeError(w, http.StatusUnauthorized, "token expired")
+					return
+				}
+				writeError(w, http.StatusUnauthorized, "invalid token")
+				return
+			}
+
+			var user models.User
+			if err := db.First(&user, claims.UserID).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					writeError(w, http.StatusUnauthorized, "user not found")
+					return
+				}
+				writeError(w, http.StatusInternalServerError, "failed to load user")
+				return
+			}
+
+			// Токен выдан другому Telegram аккаунту
+			if user.TgID != claims.TgID {
+				writeError(w, http.StatusUnauthorized, "invalid token")
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), userContextKey, &user)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// UserFromContext возвращает пользователя, положенного middleware Auth
+func UserFromContext(ctx context.Context) (*models.User, bool) {
+	user, ok := ctx.Value(userContextKey).(*models.User)
+	return user, ok
+}
+
+// tokenFromRequest извлекает токен из заголовка Authorization.
+// Для WebSocket upgrade браузер не может передать заголовки,
+// поэтому дополнительно проверяются подпротокол и query параметр token.
+func tokenFromRequest(r *http.Request) string {
+	if header := r.Header.Get("Authorization"); header != "" {
+		if token, ok := strings.CutPrefix(header, "Bearer "); ok {
+			return strings.TrimSpace(token)
+		}
+		return ""
+	}
+
+	if !websocket.IsWebSocketUpgrade(r) {
+		return ""
+	}
+
+	protocols := websocket.Subprotocols(r)
+	for i, protocol := range protocols {
+		if protocol == WSTokenProtocol && i+1 < len(protocols) {
+			return protocols[i+1]
+		}
+	}
+
+	return r.URL.Query().Get("token")
+}
+
+// writeError отправляет ошибку в формате {"error": "..."}
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error": message,
+	})
+}
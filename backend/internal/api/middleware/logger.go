@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	logger "github.com/Chelaran/yagalog"
+	chimw "github.com/go-chi/chi/v5/middleware"
+)
+
+// Logger логирует HTTP запросы через yagalog
+func Logger(log *logger.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ww := chimw.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			next.ServeHTTP(ww, r)
+
+			log.Info("%s %s -> %d (%s)", r.Method, r.URL.Path, ww.Status(), time.Since(start))
+		})
+	}
+}
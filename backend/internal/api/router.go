@@ -0,0 +1,95 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/Chelaran/mayoku/internal/api/handlers"
+	"github.com/Chelaran/mayoku/internal/api/middleware"
+	"github.com/Chelaran/mayoku/internal/config"
+	"github.com/Chelaran/mayoku/internal/game"
+	"github.com/Chelaran/mayoku/internal/storage"
+	logger "github.com/Chelaran/yagalog"
+	"github.com/go-chi/chi/v5"
+	chimw "github.com/go-chi/chi/v5/middleware"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// NewRouter создает Chi роутер со всеми маршрутами /api
+func NewRouter(cfg *config.Config, db *gorm.DB, redis *redis.Client, files *storage.Storage, hub *game.Hub) http.Handler {
+	log, _ := logger.NewLogger()
+
+	healthHandler := handlers.NewHealthHandler(db, redis)
+	authHandler := handlers.NewAuthHandler(db, cfg)
+	userHandler := handlers.NewUserHandler()
+	gameHandler := handlers.NewGameHandler(hub, db, cfg)
+	deckHandler := handlers.NewDeckHandler(db, files, cfg)
+	adminHandler := handlers.NewAdminHandler(db, cfg)
+	uploadHandler := handlers.NewUploadHandler(files, cfg)
+
+	r := chi.NewRouter()
+
+	r.Use(chimw.RequestID)
+	r.Use(chimw.RealIP)
+	r.Use(middleware.Logger(log))
+	r.Use(chimw.Recoverer)
+	r.Use(middleware.CORS(cfg.App.AllowedOrigins))
+
+	r.Route("/api", func(r chi.Router) {
+		r.Get("/health", healthHandler.Check)
+		r.Post("/auth", authHandler.Authenticate)
+
+		// Маршруты, требующие JWT
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.Auth(db, cfg.JWT.Secret))
+
+			r.Get("/user/me", userHandler.Me)
+
+			r.Get("/game/ws", gameHandler.ServeWS)
+			r.Get("/game/rooms", gameHandler.ListRooms)
+			r.Post("/game/rooms", gameHandler.CreateRoom)
+
+			r.Post("/upload", uploadHandler.Upload)
+			r.Post("/upload/presigned", uploadHandler.Presign)
+			r.Post("/upload/presigned/complete", uploadHandler.CompletePresigned)
+
+			r.Route("/decks", func(r chi.Router) {
+				r.Get("/", deckHandler.List)
+				r.Post("/", deckHandler.Create)
+				r.Get("/my", deckHandler.ListMine)
+
+				r.Route("/{id}", func(r chi.Router) {
+					r.Get("/", deckHandler.Get)
+					r.Put("/", deckHandler.Update)
+					r.Delete("/", deckHandler.Delete)
+					r.Post("/submit", deckHandler.Submit)
+
+					r.Post("/locations", deckHandler.AddLocation)
+					r.Put("/locations/{locationId}", deckHandler.UpdateLocation)
+					r.Delete("/locations/{locationId}", deckHandler.DeleteLocation)
+				})
+			})
+
+			// Модерация (админы и супер-админы)
+			r.Route("/admin", func(r chi.Router) {
+				r.Use(middleware.RequireAdmin)
+
+				r.Get("/decks", adminHandler.ListDecks)
+				r.Get("/decks/pending", adminHandler.ListPendingDecks)
+				r.Put("/decks/{id}/approve", adminHandler.ApproveDeck)
+				r.Put("/decks/{id}/reject", adminHandler.RejectDeck)
+
+				// Управление админами (только супер-админ)
+				r.Group(func(r chi.Router) {
+					r.Use(middleware.RequireSuperAdmin)
+
+					r.Get("/users/admins", adminHandler.ListAdmins)
+					r.Put("/users/{id}/make-admin", adminHandler.MakeAdmin)
+					r.Put("/users/{id}/remove-admin", adminHandler.RemoveAdmin)
+				})
+			})
+		})
+	})
+
+	return r
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Chelaran/mayoku/internal/config"
+	"github.com/Chelaran/mayoku/internal/game"
+	logger "github.com/Chelaran/yagalog"
+)
+
+// Server представляет HTTP сервер приложения
+type Server struct {
+	httpServer *http.Server
+	hub        *game.Hub
+	log        *logger.Logger
+}
+
+// NewServer создает новый Server
+func NewServer(cfg *config.Config, handler http.Handler, hub *game.Hub) *Server {
+	log, _ := logger.NewLogger()
+	return &Server{
+		httpServer: &http.Server{
+			Addr:              fmt.Sprintf("%s:%s", cfg.App.Host, cfg.App.Port),
+			Handler:           handler,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+		hub: hub,
+		log: log,
+	}
+}
+
+// Start запускает сервер и блокируется до его остановки
+func (s *Server) Start() error {
+	s.log.Info("Server listening on %s", s.httpServer.Addr)
+
+	err := s.httpServer.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to start server: %w", err)
+	}
+
+	return nil
+}
+
+// Shutdown прекращает прием запросов, отключает WebSocket клиентов и останавливает таймеры комнат
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.log.Info("Shutting down server...")
+
+	// WebSocket соединения захвачены (hijacked), http.Server их не ждет
+	err := s.httpServer.Shutdown(ctx)
+	s.hub.Shutdown()
+
+	if err != nil {
+		return fmt.Errorf("failed to shutdown server: %w", err)
+	}
+
+	return nil
+}
@@ -1,14 +1,22 @@
 package config
 
 import (
+	"time"
+
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
 // Config представляет конфигурацию приложения
 type Config struct {
 	App struct {
-		Host string `env:"APP_HOST" env-default:"0.0.0.0"`
-		Port string `env:"APP_PORT" env-default:"8080"`
+		Host            string        `env:"APP_HOST" env-default:"0.0.0.0"`
+		Port            string        `env:"APP_PORT" env-default:"8080"`
+		ShutdownTimeout time.Duration `env:"APP_SHUTDOWN_TIMEOUT" env-default:"10s"`
+
+		// Разрешенные источники для CORS (через запятую)
+		AllowedOrigins []string `env:"APP_ALLOWED_ORIGINS" env-separator:"," env-default:"http://localhost:3000"`
+		// Разрешенные Origin для WebSocket (помимо своего хоста и AllowedOrigins)
+		WSAllowedOrigins []string `env:"APP_WS_ALLOWED_ORIGINS" env-separator:"," env-default:"https://web.telegram.org"`
 	}
 
 	Postgres struct {
@@ -33,6 +41,13 @@ type Config struct {
 		SecretAccessKey string `env:"MINIO_SECRET_ACCESS_KEY" env-default:"minioadmin"`
 		UseSSL          bool   `env:"MINIO_USE_SSL" env-default:"false"`
 		BucketName      string `env:"MINIO_BUCKET_NAME" env-default:"mayoku"`
+		// Адрес MinIO, доступный из браузера (для публичных и presigned URL)
+		PublicURL string `env:"MINIO_PUBLIC_URL" env-default:"http://localhost:9000"`
+	}
+
+	Upload struct {
+		MaxSizeMB     int64         `env:"UPLOAD_MAX_SIZE_MB" env-default:"10"`
+		PresignExpiry time.Duration `env:"UPLOAD_PRESIGN_EXPIRY" env-default:"5m"`
 	}
 
 	Telegram struct {
@@ -42,6 +57,29 @@ type Config struct {
 	JWT struct {
 		Secret string `env:"JWT_SECRET" env-default:"change-me-in-production"`
 	}
+
+	Game struct {
+		MinPlayers  int `env:"GAME_MIN_PLAYERS" env-default:"3"`
+		MaxPlayers  int `env:"GAME_MAX_PLAYERS" env-default:"10"`
+		MaxSpyCount int `env:"GAME_MAX_SPY_COUNT" env-default:"2"`
+		// Таблица режима "Авто": "игроков от:шпионов" через запятую
+		AutoSpies map[int]int `env:"GAME_AUTO_SPIES" env-separator:"," env-default:"3:1,7:2"`
+	}
+
+	Admin struct {
+		// Telegram ID пользователей, которые получают права супер-админа при входе и запуске
+		SuperAdminTgIDs []int64 `env:"SUPER_ADMIN_TG_IDS" env-separator:","`
+	}
+}
+
+// IsSuperAdminTgID проверяет, указан ли Telegram ID в SUPER_ADMIN_TG_IDS
+func (c *Config) IsSuperAdminTgID(tgID int64) bool {
+	for _, id := range c.Admin.SuperAdminTgIDs {
+		if id == tgID {
+			return true
+		}
+	}
+	return false
 }
 
 // Load загружает конфигурацию из .env файла
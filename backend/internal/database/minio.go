@@ -5,8 +5,10 @@ import (
 	"fmt"
 
 	"github.com/Chelaran/mayoku/internal/config"
+	"github.com/Chelaran/mayoku/internal/storage"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
 )
 
 // ConnectMinIO создает подключение к MinIO
@@ -33,5 +35,24 @@ func ConnectMinIO(cfg *config.Config) (*minio.Client, error) {
 		}
 	}
 
+	// Картинки локаций читаются браузером напрямую, поэтому обработанные картинки публичны на чтение.
+	// Сырые загрузки (с EXIF) остаются закрытыми
+	policy := fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::%s/%s*"]}]}`, cfg.MinIO.BucketName, storage.ImagesPrefix)
+	if err := client.SetBucketPolicy(ctx, cfg.MinIO.BucketName, policy); err != nil {
+		return nil, fmt.Errorf("failed to set bucket policy: %w", err)
+	}
+
+	// Сырые загрузки, для которых так и не вызвали /complete, удаляются через сутки
+	rules := lifecycle.NewConfiguration()
+	rules.Rules = []lifecycle.Rule{{
+		ID:         "expire-raw-uploads",
+		Status:     "Enabled",
+		RuleFilter: lifecycle.Filter{Prefix: storage.UploadsPrefix},
+		Expiration: lifecycle.Expiration{Days: 1},
+	}}
+	if err := client.SetBucketLifecycle(ctx, cfg.MinIO.BucketName, rules); err != nil {
+		return nil, fmt.Errorf("failed to set bucket lifecycle: %w", err)
+	}
+
 	return client, nil
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/Chelaran/mayoku/internal/config"
+	"github.com/Chelaran/mayoku/internal/models"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -27,3 +28,37 @@ func ConnectPostgres(cfg *config.Config) (*gorm.DB, error) {
 
 	return db, nil
 }
+
+// Migrate применяет AutoMigrate для всех моделей
+func Migrate(db *gorm.DB) error {
+	err := db.AutoMigrate(
+		&models.User{},
+		&models.Deck{},
+		&models.Location{},
+		&models.GameHistory{},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	return nil
+}
+
+// BootstrapSuperAdmins выдает права супер-админа уже зарегистрированным пользователям из списка
+func BootstrapSuperAdmins(db *gorm.DB, tgIDs []int64) error {
+	if len(tgIDs) == 0 {
+		return nil
+	}
+
+	err := db.Model(&models.User{}).
+		Where("tg_id IN ?", tgIDs).
+		Updates(map[string]interface{}{
+			"is_admin":       true,
+			"is_super_admin": true,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to bootstrap super-admins: %w", err)
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package game
 import (
 	"encoding/json"
 	"sync"
+	"time"
 
 	logger "github.com/Chelaran/yagalog"
 	"github.com/gorilla/websocket"
@@ -12,6 +13,23 @@ var (
 	ErrRoomExists     = &GameError{Message: "room already exists"}
 	ErrRoomNotFound   = &GameError{Message: "room not found"}
 	ErrPlayerNotFound = &GameError{Message: "player not found"}
+
+	ErrSelfAccusation  = &GameError{Message: "cannot accuse yourself"}
+	ErrAccusationLimit = &GameError{Message: "no accusations left this round"}
+
+	ErrPlayerEliminated = &GameError{Message: "you have been eliminated"}
+
+	ErrNotYourQuestion = &GameError{Message: "it is not your turn to ask"}
+	ErrNoAskingBack    = &GameError{Message: "cannot ask back the player who asked you"}
+
+	ErrGamePaused = &GameError{Message: "game is paused"}
+)
+
+const (
+	writeWait      = 10 * time.Second    // Время на запись сообщения
+	pongWait       = 60 * time.Second    // Время ожидания pong от клиента
+	pingPeriod     = (pongWait * 9) / 10 // Период отправки ping
+	maxMessageSize = 4096                // Максимальный размер входящего сообщения
 )
 
 type GameError struct {
@@ -27,6 +45,7 @@ type Client struct {
 	mu        sync.Mutex
 	conn      *websocket.Conn
 	send      chan []byte
+	closed    bool
 	hub       *Hub
 	room      *Room
 	userID    uint
@@ -54,12 +73,22 @@ func NewClient(conn *websocket.Conn, hub *Hub, userID uint, tgID int64, username
 // ReadPump читает сообщения из WebSocket
 func (c *Client) ReadPump() {
 	defer func() {
+		c.hub.Unregister(c)
+		c.Close()
 		c.conn.Close()
+		// Игрок сохраняет место и роль до истечения ReconnectGracePeriod
 		if c.room != nil {
-			c.room.RemovePlayer(c.userID)
+			c.room.Disconnect(c.userID, c)
 		}
 	}()
 
+	// Мобильные клиенты часто теряют соединение без close frame - обнаруживаем это по pong
+	c.conn.SetReadLimit(maxMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
 	for {
 		var msg ClientMessage
 		if err := c.conn.ReadJSON(&msg); err != nil {
@@ -75,11 +104,16 @@ func (c *Client) ReadPump() {
 
 // WritePump отправляет сообщения в WebSocket
 func (c *Client) WritePump() {
-	defer c.conn.Close()
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
 
 	for {
 		select {
 		case message, ok := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
 			if !ok {
 				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
 				return
@@ -89,6 +123,12 @@ func (c *Client) WritePump() {
 				c.log.Error("Failed to write message: %v", err)
 				return
 			}
+
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
 		}
 	}
 }
@@ -109,6 +149,10 @@ func (c *Client) SendRaw(data []byte) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	if c.closed {
+		return
+	}
+
 	select {
 	case c.send <- data:
 	default:
@@ -116,6 +160,19 @@ func (c *Client) SendRaw(data []byte) {
 	}
 }
 
+// Close закрывает канал отправки, после чего WritePump закрывает соединение
+func (c *Client) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return
+	}
+
+	c.closed = true
+	close(c.send)
+}
+
 // SendError отправляет ошибку клиенту
 func (c *Client) SendError(err error) {
 	msg := WSMessage{
@@ -144,6 +201,18 @@ func (c *Client) handleMessage(msg ClientMessage) {
 		c.handleKickPlayer(msg.Payload)
 	case "update_room_settings":
 		c.handleUpdateRoomSettings(msg.Payload)
+	case "transfer_host":
+		c.handleTransferHost(msg.Payload)
+	case "pass_question":
+		c.handlePassQuestion(msg.Payload)
+	case "start_game":
+		c.handleStartGame()
+	case "rematch":
+		c.handleRematch(msg.Payload)
+	case "pause_game":
+		c.handlePauseGame()
+	case "resume_game":
+		c.handleResumeGame()
 	default:
 		c.SendError(&GameError{Message: "unknown message type"})
 	}
@@ -171,18 +240,37 @@ func (c *Client) handleKickPlayer(payload json.RawMessage) {
 	}
 }
 
-// handleUpdateRoomSettings обрабатывает обновление настроек комнаты (только админ комнаты)
-func (c *Client) handleUpdateRoomSettings(payload json.RawMessage) {
+// handleTransferHost обрабатывает передачу прав хоста (только админ комнаты)
+func (c *Client) handleTransferHost(payload json.RawMessage) {
+	if c.room == nil {
+		c.SendError(&GameError{Message: "not in a room"})
+		return
+	}
+
+	var req struct {
+		TargetUserID uint `json:"target_user_id"`
+	}
+
+	if err := json.Unmarshal(payload, &req); err != nil {
+		c.SendError(&GameError{Message: "invalid payload"})
+		return
+	}
+
+	if err := c.room.TransferHost(c.userID, req.TargetUserID); err != nil {
+		c.SendError(err)
+		return
+	}
+}
+
+// handlePassQuestion обрабатывает передачу вопроса следующему игроку
+func (c *Client) handlePassQuestion(payload json.RawMessage) {
 	if c.room == nil {
 		c.SendError(&GameError{Message: "not in a room"})
 		return
 	}
 
 	var req struct {
-		MaxPlayers *int  `json:"max_players,omitempty"`
-		SpyCount   *int  `json:"spy_count,omitempty"`
-		Duration   *int  `json:"duration,omitempty"`
-		DeckID     *uint `json:"deck_id,omitempty"`
+		TargetUserID uint `json:"target_user_id"`
 	}
 
 	if err := json.Unmarshal(payload, &req); err != nil {
@@ -190,7 +278,91 @@ func (c *Client) handleUpdateRoomSettings(payload json.RawMessage) {
 		return
 	}
 
-	if err := c.room.UpdateSettings(c.userID, req.MaxPlayers, req.SpyCount, req.Duration, req.DeckID); err != nil {
+	if err := c.room.PassQuestion(c.userID, req.TargetUserID); err != nil {
+		c.SendError(err)
+		return
+	}
+}
+
+// handleStartGame обрабатывает ручной запуск игры (только админ комнаты)
+func (c *Client) handleStartGame() {
+	if c.room == nil {
+		c.SendError(&GameError{Message: "not in a room"})
+		return
+	}
+
+	if err := c.room.StartGame(c.userID); err != nil {
+		c.SendError(err)
+		return
+	}
+}
+
+// handleRematch обрабатывает повторную игру в завершенной комнате (только админ комнаты)
+func (c *Client) handleRematch(payload json.RawMessage) {
+	if c.room == nil {
+		c.SendError(&GameError{Message: "not in a room"})
+		return
+	}
+
+	var req struct {
+		AutoStart *bool `json:"auto_start,omitempty"`
+	}
+
+	// Payload необязателен
+	if len(payload) > 0 {
+		if err := json.Unmarshal(payload, &req); err != nil {
+			c.SendError(&GameError{Message: "invalid payload"})
+			return
+		}
+	}
+
+	if err := c.room.Rematch(c.userID, req.AutoStart); err != nil {
+		c.SendError(err)
+		return
+	}
+}
+
+// handlePauseGame обрабатывает постановку игры на паузу (только админ комнаты)
+func (c *Client) handlePauseGame() {
+	if c.room == nil {
+		c.SendError(&GameError{Message: "not in a room"})
+		return
+	}
+
+	if err := c.room.Pause(c.userID); err != nil {
+		c.SendError(err)
+		return
+	}
+}
+
+// handleResumeGame обрабатывает снятие игры с паузы (только админ комнаты)
+func (c *Client) handleResumeGame() {
+	if c.room == nil {
+		c.SendError(&GameError{Message: "not in a room"})
+		return
+	}
+
+	if err := c.room.Resume(c.userID); err != nil {
+		c.SendError(err)
+		return
+	}
+}
+
+// handleUpdateRoomSettings обрабатывает обновление настроек комнаты (только админ комнаты)
+func (c *Client) handleUpdateRoomSettings(payload json.RawMessage) {
+	if c.room == nil {
+		c.SendError(&GameError{Message: "not in a room"})
+		return
+	}
+
+	var req SettingsUpdate
+
+	if err := json.Unmarshal(payload, &req); err != nil {
+		c.SendError(&GameError{Message: "invalid payload"})
+		return
+	}
+
+	if err := c.room.UpdateSettings(c.userID, req); err != nil {
 		c.SendError(err)
 		return
 	}
@@ -221,8 +393,9 @@ func (c *Client) handleJoinRoom(payload json.RawMessage) {
 		return
 	}
 
-	// Добавляем игрока в комнату
-	if err := room.AddPlayer(c.userID, c.tgID, c.username, c.avatarURL, c); err != nil {
+	// Добавляем игрока в комнату (или возвращаем на его место)
+	rejoined, err := room.AddPlayer(c.userID, c.tgID, c.username, c.avatarURL, c)
+	if err != nil {
 		c.SendError(err)
 		return
 	}
@@ -236,8 +409,14 @@ func (c *Client) handleJoinRoom(payload json.RawMessage) {
 		Payload: map[string]interface{}{
 			"room_id":       req.RoomID,
 			"is_room_admin": isRoomAdmin,
+			"rejoined":      rejoined,
 		},
 	})
+
+	// При переподключении во время игры возвращаем игроку его роль
+	if rejoined {
+		room.SendPrivateState(c.userID)
+	}
 }
 
 // handleSetReady обрабатывает установку готовности
@@ -291,16 +470,26 @@ func (c *Client) handleVoteAnswer(payload json.RawMessage) {
 		return
 	}
 
+	// { vote: true/false } или { abstain: true }
 	var req struct {
-		Vote bool `json:"vote"`
+		Vote    *bool `json:"vote"`
+		Abstain bool  `json:"abstain"`
 	}
 
-	if err := json.Unmarshal(payload, &req); err != nil {
+	if err := json.Unmarshal(payload, &req); err != nil || (req.Vote == nil && !req.Abstain) {
 		c.SendError(&GameError{Message: "invalid payload"})
 		return
 	}
 
-	if err := c.room.Vote(c.userID, req.Vote); err != nil {
+	choice := VoteAbstain
+	if !req.Abstain {
+		choice = VoteAgainst
+		if *req.Vote {
+			choice = VoteFor
+		}
+	}
+
+	if err := c.room.Vote(c.userID, choice); err != nil {
 		c.SendError(err)
 		return
 	}
@@ -314,15 +503,15 @@ func (c *Client) handleSpyGuess(payload json.RawMessage) {
 	}
 
 	var req struct {
-		LocationName string `json:"location_name"`
+		LocationID uint `json:"location_id"`
 	}
 
-	if err := json.Unmarshal(payload, &req); err != nil {
+	if err := json.Unmarshal(payload, &req); err != nil || req.LocationID == 0 {
 		c.SendError(&GameError{Message: "invalid payload"})
 		return
 	}
 
-	if err := c.room.SpyGuess(c.userID, req.LocationName); err != nil {
+	if err := c.room.SpyGuess(c.userID, req.LocationID); err != nil {
 		c.SendError(err)
 		return
 	}
@@ -2,6 +2,7 @@ package game
 
 import (
 	"sync"
+	"time"
 
 	logger "github.com/Chelaran/yagalog"
 	"github.com/redis/go-redis/v9"
@@ -10,21 +11,25 @@ import (
 
 // Hub управляет всеми комнатами и клиентами
 type Hub struct {
-	mu    sync.RWMutex
-	rooms map[string]*Room // room_id -> Room
-	db    *gorm.DB
-	redis *redis.Client
-	log   *logger.Logger
+	mu      sync.RWMutex
+	rooms   map[string]*Room     // room_id -> Room
+	clients map[*Client]struct{} // Все подключенные клиенты
+	limits  Limits
+	db      *gorm.DB
+	redis   *redis.Client
+	log     *logger.Logger
 }
 
 // NewHub создает новый Hub
-func NewHub(db *gorm.DB, redis *redis.Client) *Hub {
+func NewHub(db *gorm.DB, redis *redis.Client, limits Limits) *Hub {
 	log, _ := logger.NewLogger()
 	return &Hub{
-		rooms: make(map[string]*Room),
-		db:    db,
-		redis: redis,
-		log:   log,
+		rooms:   make(map[string]*Room),
+		clients: make(map[*Client]struct{}),
+		limits:  limits,
+		db:      db,
+		redis:   redis,
+		log:     log,
 	}
 }
 
@@ -38,14 +43,25 @@ func (h *Hub) CreateRoom(roomID string, createdBy uint, deckID uint, deckName st
 		return nil, ErrRoomExists
 	}
 
-	room := NewRoom(roomID, createdBy, deckID, deckName, maxPlayers, spyCount, duration, h.db, h.redis)
+	room := NewRoom(roomID, createdBy, deckID, deckName, maxPlayers, spyCount, duration, h.limits, h.db, h.redis)
+	room.onEmpty = func() { h.removeIfEmpty(roomID) }
 	h.rooms[roomID] = room
 
+	// Комната, в которую так никто и не вошел, не должна висеть в лобби
+	time.AfterFunc(EmptyRoomTTL, func() {
+		h.removeIfEmpty(roomID)
+	})
+
 	h.log.Info("Room created: %s (created by: %d)", roomID, createdBy)
 
 	return room, nil
 }
 
+// Limits возвращает серверные ограничения настроек комнат
+func (h *Hub) Limits() Limits {
+	return h.limits
+}
+
 // GetRoom возвращает комнату по ID
 func (h *Hub) GetRoom(roomID string) (*Room, bool) {
 	h.mu.RLock()
@@ -64,15 +80,61 @@ func (h *Hub) DeleteRoom(roomID string) {
 	h.log.Info("Room deleted: %s", roomID)
 }
 
-// ListRooms возвращает список активных комнат
-func (h *Hub) ListRooms() []string {
+// removeIfEmpty удаляет комнату, в которой не осталось игроков
+func (h *Hub) removeIfEmpty(roomID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	room, exists := h.rooms[roomID]
+	if !exists || !room.closeIfEmpty() {
+		return
+	}
+
+	delete(h.rooms, roomID)
+	h.log.Info("Empty room deleted: %s", roomID)
+}
+
+// ListRooms возвращает краткую информацию обо всех комнатах
+func (h *Hub) ListRooms() []RoomSummary {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
-	rooms := make([]string, 0, len(h.rooms))
-	for id := range h.rooms {
-		rooms = append(rooms, id)
+	rooms := make([]RoomSummary, 0, len(h.rooms))
+	for _, room := range h.rooms {
+		rooms = append(rooms, room.Summary())
 	}
 
 	return rooms
 }
+
+// Register регистрирует подключенного клиента
+func (h *Hub) Register(client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.clients[client] = struct{}{}
+}
+
+// Unregister удаляет клиента из списка подключенных
+func (h *Hub) Unregister(client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.clients, client)
+}
+
+// Shutdown останавливает таймеры всех комнат и отключает клиентов
+func (h *Hub) Shutdown() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, room := range h.rooms {
+		room.Close()
+	}
+
+	for client := range h.clients {
+		client.Close()
+	}
+
+	h.log.Info("Hub stopped: %d rooms, %d clients", len(h.rooms), len(h.clients))
+}
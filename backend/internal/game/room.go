@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/rand"
+	"slices"
+	"sort"
 	"sync"
 	"time"
 
@@ -23,10 +25,20 @@ type Room struct {
 	redis   *redis.Client
 	log     *logger.Logger
 	timer   *time.Timer
+	limits  Limits
+
+	voteTimer       *time.Timer // Дедлайн текущего голосования
+	lastChanceTimer *time.Timer // Дедлайн последнего шанса шпиона
+	turnTimer       *time.Timer // Дедлайн хода в финальном раунде обвинений
+
+	graceTimers map[uint]*time.Timer // user_id -> таймер удаления отключившегося игрока
+
+	onEmpty func() // Вызывается, когда из комнаты ушел последний игрок
+	closed  bool   // Комната удалена из хаба, войти в нее нельзя
 }
 
 // NewRoom создает новую комнату
-func NewRoom(roomID string, createdBy uint, deckID uint, deckName string, maxPlayers, spyCount, duration int, db *gorm.DB, redis *redis.Client) *Room {
+func NewRoom(roomID string, createdBy uint, deckID uint, deckName string, maxPlayers, spyCount, duration int, limits Limits, db *gorm.DB, redis *redis.Client) *Room {
 	log, _ := logger.NewLogger()
 	room := &Room{
 		state: &RoomState{
@@ -38,13 +50,29 @@ func NewRoom(roomID string, createdBy uint, deckID uint, deckName string, maxPla
 			MaxPlayers: maxPlayers,
 			SpyCount:   spyCount,
 			Duration:   duration,
+			TimerMode:  TimerFixed,
 			CreatedBy:  createdBy,
+			HostID:     createdBy,
 			CreatedAt:  time.Now(),
+
+			PerPlayerSeconds:  DefaultPerPlayerSeconds,
+			VoteTimeout:       DefaultVoteTimeout,
+			MissingVotePolicy: DefaultMissingVotePolicy,
+
+			AccusationsPerPlayer: DefaultAccusationsPerPlayer,
+			VoteCooldown:         DefaultVoteCooldown,
+			LastChanceSeconds:    DefaultLastChanceSeconds,
+			TimeoutRule:          DefaultTimeoutRule,
+			FinalTurnSeconds:     DefaultFinalTurnSeconds,
+			Rounds:               DefaultRounds,
+			AutoStart:            true,
 		},
-		clients: make(map[uint]*Client),
-		db:      db,
-		redis:   redis,
-		log:     log,
+		clients:     make(map[uint]*Client),
+		limits:      limits,
+		db:          db,
+		redis:       redis,
+		log:         log,
+		graceTimers: make(map[uint]*time.Timer),
 	}
 
 	// Сохраняем в Redis
@@ -53,35 +81,127 @@ func NewRoom(roomID string, createdBy uint, deckID uint, deckName string, maxPla
 	return room
 }
 
-// IsRoomAdmin проверяет, является ли пользователь админом комнаты (создателем)
+// IsRoomAdmin проверяет, является ли пользователь админом комнаты (текущим хостом)
 func (r *Room) IsRoomAdmin(userID uint) bool {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
-	return r.state.CreatedBy == userID
+	return r.state.HostID == userID
 }
 
-// AddPlayer добавляет игрока в комнату
-func (r *Room) AddPlayer(userID uint, tgID int64, username, avatarURL string, client *Client) error {
+// TransferHost передает права хоста другому игроку (только текущий хост)
+func (r *Room) TransferHost(hostUserID, targetUserID uint) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	// Проверяем лимит игроков
-	if len(r.state.Players) >= r.state.MaxPlayers {
-		return fmt.Errorf("room is full")
+	if r.state.HostID != hostUserID {
+		return fmt.Errorf("only room admin can transfer host")
+	}
+
+	if hostUserID == targetUserID {
+		return fmt.Errorf("you are already the host")
+	}
+
+	target, exists := r.state.Players[targetUserID]
+	if !exists {
+		return fmt.Errorf("player not found in room")
+	}
+
+	if !target.IsConnected {
+		return fmt.Errorf("player is disconnected")
+	}
+
+	r.setHost(targetUserID, "transferred")
+
+	r.saveToRedis()
+	r.broadcastState()
+
+	return nil
+}
+
+// migrateHost передает права хоста игроку, дольше всех находящемуся в комнате.
+// При connectedOnly права переходят только подключенному игроку
+func (r *Room) migrateHost(reason string, connectedOnly bool) {
+	var next *Player
+	for _, p := range r.state.Players {
+		if p.UserID == r.state.HostID {
+			continue
+		}
+
+		// Подключенные игроки в приоритете
+		if next == nil ||
+			(p.IsConnected && !next.IsConnected) ||
+			(p.IsConnected == next.IsConnected && p.JoinedAt.Before(next.JoinedAt)) {
+			next = p
+		}
+	}
+
+	if next == nil || (connectedOnly && !next.IsConnected) {
+		return
+	}
+
+	r.setHost(next.UserID, reason)
+}
+
+// setHost меняет хоста и уведомляет игроков событием host_changed
+func (r *Room) setHost(userID uint, reason string) {
+	previous := r.state.HostID
+	r.state.HostID = userID
+
+	r.log.Info("Room %s host changed: %d -> %d (%s)", r.state.RoomID, previous, userID, reason)
+
+	r.broadcastMessage(WSMessage{
+		Type: "host_changed",
+		Payload: map[string]interface{}{
+			"room_id":          r.state.RoomID,
+			"host_id":          userID,
+			"previous_host_id": previous,
+			"reason":           reason,
+		},
+	})
+}
+
+// AddPlayer добавляет игрока в комнату.
+// Если игрок уже сидит в комнате (переподключение), к нему привязывается новый клиент
+// и возвращается rejoined = true.
+func (r *Room) AddPlayer(userID uint, tgID int64, username, avatarURL string, client *Client) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Пустую комнату хаб уже удалил, но клиент успел получить на нее ссылку
+	if r.closed {
+		return false, ErrRoomNotFound
+	}
+
+	// Игрок уже в комнате - переподключение
+	if player, exists := r.state.Players[userID]; exists {
+		r.rebindPlayer(player, client)
+		return true, nil
+	}
+
+	// Новые игроки входят только до начала игры
+	if r.state.Status != StatusWaiting {
+		return false, fmt.Errorf("game already started")
+	}
+
+	// Между раундами матча места держатся за теми же игроками
+	if r.midMatch() {
+		return false, fmt.Errorf("match in progress")
 	}
 
-	// Проверяем, не добавлен ли уже
-	if _, exists := r.state.Players[userID]; exists {
-		return fmt.Errorf("player already in room")
+	// Проверяем лимит игроков
+	if len(r.state.Players) >= r.state.MaxPlayers {
+		return false, fmt.Errorf("room is full")
 	}
 
 	// Добавляем игрока
 	r.state.Players[userID] = &Player{
-		UserID:    userID,
-		TgID:      tgID,
-		Username:  username,
-		AvatarURL: avatarURL,
-		IsReady:   false,
+		UserID:      userID,
+		TgID:        tgID,
+		Username:    username,
+		AvatarURL:   avatarURL,
+		JoinedAt:    time.Now(),
+		IsReady:     false,
+		IsConnected: true,
 	}
 
 	r.clients[userID] = client
@@ -92,7 +212,113 @@ func (r *Room) AddPlayer(userID uint, tgID int64, username, avatarURL string, cl
 	// Отправляем обновление всем
 	r.broadcastState()
 
-	return nil
+	return false, nil
+}
+
+// rebindPlayer привязывает новое соединение к уже сидящему игроку
+func (r *Room) rebindPlayer(player *Player, client *Client) {
+	// Старое соединение (например, другая вкладка) закрываем
+	if old, exists := r.clients[player.UserID]; exists && old != client {
+		old.SendMessage(WSMessage{
+			Type: "session_replaced",
+			Payload: map[string]interface{}{
+				"room_id": r.state.RoomID,
+			},
+		})
+		old.Close()
+	}
+
+	if timer, exists := r.graceTimers[player.UserID]; exists {
+		timer.Stop()
+		delete(r.graceTimers, player.UserID)
+	}
+
+	r.clients[player.UserID] = client
+	player.IsConnected = true
+	player.DisconnectedAt = nil
+
+	r.log.Info("Player %d reconnected to room %s", player.UserID, r.state.RoomID)
+
+	r.saveToRedis()
+	r.broadcastState()
+}
+
+// SendPrivateState повторно отправляет игроку его роль, если игра идет
+func (r *Room) SendPrivateState(userID uint) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	switch r.state.Status {
+	case StatusPlaying, StatusVoting, StatusFinalAccusation, StatusLastChance:
+	default:
+		return
+	}
+
+	player, exists := r.state.Players[userID]
+	client, connected := r.clients[userID]
+	if !exists || !connected {
+		return
+	}
+
+	client.SendMessage(r.gameStartedMessage(player))
+}
+
+// Disconnect помечает игрока отключенным, сохраняя его место и роль.
+// Если игрок не вернется за ReconnectGracePeriod, он удаляется из комнаты.
+func (r *Room) Disconnect(userID uint, client *Client) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Игрок уже переподключился другим клиентом или был выгнан
+	if current, exists := r.clients[userID]; !exists || current != client {
+		return
+	}
+	delete(r.clients, userID)
+
+	player, exists := r.state.Players[userID]
+	if !exists {
+		return
+	}
+
+	now := time.Now()
+	player.IsConnected = false
+	player.DisconnectedAt = &now
+
+	r.graceTimers[userID] = time.AfterFunc(ReconnectGracePeriod, func() {
+		r.handleGraceExpired(userID)
+	})
+
+	// Вне игры без хоста комнату не настроить и не запустить - права переходят сразу,
+	// не дожидаясь ReconnectGracePeriod
+	if r.state.HostID == userID && (r.state.Status == StatusWaiting || r.state.Status == StatusFinished) {
+		r.migrateHost("host_disconnected", true)
+	}
+
+	r.log.Info("Player %d disconnected from room %s", userID, r.state.RoomID)
+
+	r.saveToRedis()
+	r.broadcastState()
+
+	// Отключившийся не должен блокировать голосование
+	if r.state.Status == StatusVoting {
+		r.checkVotingComplete()
+	}
+}
+
+// handleGraceExpired удаляет игрока, не вернувшегося за ReconnectGracePeriod
+func (r *Room) handleGraceExpired(userID uint) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.graceTimers, userID)
+
+	player, exists := r.state.Players[userID]
+	if !exists || player.IsConnected {
+		return
+	}
+
+	r.log.Info("Player %d did not reconnect to room %s", userID, r.state.RoomID)
+	r.removePlayer(userID)
 }
 
 // RemovePlayer удаляет игрока из комнаты
@@ -100,25 +326,134 @@ func (r *Room) RemovePlayer(userID uint) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	r.removePlayer(userID)
+}
+
+// removePlayer удаляет игрока и, если идет игра, проверяет, может ли она продолжаться
+func (r *Room) removePlayer(userID uint) {
+	player, exists := r.state.Players[userID]
+	if !exists {
+		return
+	}
+
 	delete(r.state.Players, userID)
 	delete(r.clients, userID)
+	if timer, exists := r.graceTimers[userID]; exists {
+		timer.Stop()
+		delete(r.graceTimers, userID)
+	}
 
-	// Если комната пуста, можно удалить
+	// Пустая комната удаляется из хаба (вне блокировки комнаты: хаб берет ее сам)
 	if len(r.state.Players) == 0 {
+		r.stopTimers()
 		r.state.Status = StatusFinished
+		r.saveToRedis()
+		if r.onEmpty != nil {
+			go r.onEmpty()
+		}
+		return
+	}
+
+	// Хост ушел - права переходят дольше всех находящемуся в комнате
+	if r.state.HostID == userID {
+		r.migrateHost("host_left", false)
+	}
+
+	r.endShortMatch()
+
+	r.reassignAsker(userID)
+
+	if r.state.Status == StatusPlaying || r.state.Status == StatusVoting || r.state.Status == StatusFinalAccusation {
+		if (player.Role == RoleSpy && !r.hasSpies()) || len(r.state.Players) < r.limits.MinPlayers {
+			r.abortGame("player_left")
+			return
+		}
+
+		if r.state.Status == StatusVoting {
+			if r.state.Voting.TargetUserID == userID {
+				r.cancelVoting()
+			} else {
+				delete(r.state.Voting.Votes, userID)
+				r.checkVotingComplete()
+			}
+		}
+	}
+
+	// Ушел игрок, чей ход в финальном раунде - ход переходит дальше
+	if r.state.Status == StatusFinalAccusation && r.currentFinalTurn() == userID {
+		r.nextFinalTurn()
+		return
+	}
+
+	// Пойманный шпион ушел, не использовав последний шанс
+	if r.state.Status == StatusLastChance && r.state.LastChance.SpyID == userID {
+		r.finishGame("locals", WinSpyCaught)
+		return
 	}
 
 	r.saveToRedis()
 	r.broadcastState()
 }
 
+// hasSpies проверяет, остались ли в игре шпионы
+func (r *Room) hasSpies() bool {
+	return r.activeSpies() > 0
+}
+
+// activeSpies возвращает количество шпионов в комнате, еще не выбывших из игры
+func (r *Room) activeSpies() int {
+	count := 0
+	for _, id := range r.state.SpyIDs {
+		if p, exists := r.state.Players[id]; exists && !p.Eliminated {
+			count++
+		}
+	}
+	return count
+}
+
+// eliminateSpy выводит пойманного шпиона из игры.
+// Возвращает false, если исключение выключено или это последний шпион - тогда игра заканчивается.
+func (r *Room) eliminateSpy(userID uint, reason string) bool {
+	if !r.state.EliminateCaughtSpies || r.activeSpies() <= 1 {
+		return false
+	}
+
+	r.state.Players[userID].Eliminated = true
+	r.reassignAsker(userID)
+
+	r.log.Info("Spy %d eliminated in room %s (%s)", userID, r.state.RoomID, reason)
+
+	r.broadcastMessage(WSMessage{
+		Type: "spy_eliminated",
+		Payload: map[string]interface{}{
+			"room_id":    r.state.RoomID,
+			"user_id":    userID,
+			"reason":     reason,
+			"spies_left": r.activeSpies(),
+		},
+	})
+
+	return true
+}
+
+// spyPartners возвращает остальных шпионов (если правило раскрытия включено)
+func (r *Room) spyPartners(userID uint) []uint {
+	partners := make([]uint, 0, len(r.state.SpyIDs))
+	for _, id := range r.state.SpyIDs {
+		if id != userID {
+			partners = append(partners, id)
+		}
+	}
+	return partners
+}
+
 // KickPlayer удаляет игрока из комнаты (только админ комнаты)
 func (r *Room) KickPlayer(adminUserID, targetUserID uint) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	// Проверяем права админа комнаты
-	if r.state.CreatedBy != adminUserID {
+	if r.state.HostID != adminUserID {
 		return fmt.Errorf("only room admin can kick players")
 	}
 
@@ -132,11 +467,8 @@ func (r *Room) KickPlayer(adminUserID, targetUserID uint) error {
 		return fmt.Errorf("player not found in room")
 	}
 
-	// Удаляем игрока
-	delete(r.state.Players, targetUserID)
+	// Отправляем уведомление выгнанному игроку
 	if client, exists := r.clients[targetUserID]; exists {
-		delete(r.clients, targetUserID)
-		// Отправляем уведомление выгнанному игроку
 		msg := WSMessage{
 			Type: "kicked_from_room",
 			Payload: map[string]interface{}{
@@ -147,19 +479,19 @@ func (r *Room) KickPlayer(adminUserID, targetUserID uint) error {
 		client.SendMessage(msg)
 	}
 
-	r.saveToRedis()
-	r.broadcastState()
+	// Удаляем игрока
+	r.removePlayer(targetUserID)
 
 	return nil
 }
 
 // UpdateSettings обновляет настройки комнаты (только админ комнаты)
-func (r *Room) UpdateSettings(adminUserID uint, maxPlayers, spyCount, duration *int, deckID *uint) error {
+func (r *Room) UpdateSettings(adminUserID uint, update SettingsUpdate) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	// Проверяем права админа комнаты
-	if r.state.CreatedBy != adminUserID {
+	if r.state.HostID != adminUserID {
 		return fmt.Errorf("only room admin can update settings")
 	}
 
@@ -168,35 +500,107 @@ func (r *Room) UpdateSettings(adminUserID uint, maxPlayers, spyCount, duration *
 		return fmt.Errorf("cannot update settings: game already started")
 	}
 
-	// Обновляем настройки
-	if maxPlayers != nil {
-		if *maxPlayers < 3 || *maxPlayers > 10 {
-			return fmt.Errorf("max_players must be between 3 and 10")
-		}
-		r.state.MaxPlayers = *maxPlayers
+	if err := r.limits.ValidateSettings(update); err != nil {
+		return err
 	}
 
-	if spyCount != nil {
-		if *spyCount < 1 || *spyCount > 2 {
-			return fmt.Errorf("spy_count must be 1 or 2")
-		}
-		r.state.SpyCount = *spyCount
+	// Посреди матча нельзя сделать раундов меньше, чем уже сыграно
+	if update.Rounds != nil && !r.matchOver() && *update.Rounds < r.state.Round {
+		return fmt.Errorf("rounds must be at least %d: already played", r.state.Round)
 	}
 
-	if duration != nil {
-		if *duration < 3 || *duration > 15 {
-			return fmt.Errorf("duration must be between 3 and 15 minutes")
+	// Проверяем существование колоды до изменения настроек
+	var deck models.Deck
+	if update.DeckID != nil {
+		if err := r.db.First(&deck, *update.DeckID).Error; err != nil {
+			return fmt.Errorf("deck not found")
 		}
-		r.state.Duration = *duration
-	}
 
-	if deckID != nil {
-		// Проверяем существование колоды
-		var deck models.Deck
-		if err := r.db.First(&deck, *deckID).Error; err != nil {
-			return fmt.Errorf("deck not found")
+		// Как и при создании комнаты: только одобренная колода или своя
+		if !deck.IsAvailableTo(adminUserID) {
+			return fmt.Errorf("deck is not available")
 		}
-		r.state.DeckID = *deckID
+	}
+
+	// Обновляем настройки
+	if update.MaxPlayers != nil {
+		r.state.MaxPlayers = *update.MaxPlayers
+	}
+
+	if update.SpyCount != nil {
+		r.state.SpyCount = *update.SpyCount
+	}
+
+	if update.Duration != nil {
+		r.state.Duration = *update.Duration
+	}
+
+	if update.TimerMode != nil {
+		r.state.TimerMode = *update.TimerMode
+	}
+
+	if update.PerPlayerSeconds != nil {
+		r.state.PerPlayerSeconds = *update.PerPlayerSeconds
+	}
+
+	if update.FreezeOnVote != nil {
+		r.state.FreezeOnVote = *update.FreezeOnVote
+	}
+
+	if update.VoteTimeout != nil {
+		r.state.VoteTimeout = *update.VoteTimeout
+	}
+
+	if update.MissingVotePolicy != nil {
+		r.state.MissingVotePolicy = *update.MissingVotePolicy
+	}
+
+	if update.AccusationsPerPlayer != nil {
+		r.state.AccusationsPerPlayer = *update.AccusationsPerPlayer
+	}
+
+	if update.VoteCooldown != nil {
+		r.state.VoteCooldown = *update.VoteCooldown
+	}
+
+	if update.RevealSpyPartners != nil {
+		r.state.RevealSpyPartners = *update.RevealSpyPartners
+	}
+
+	if update.EliminateCaughtSpies != nil {
+		r.state.EliminateCaughtSpies = *update.EliminateCaughtSpies
+	}
+
+	if update.SpyLastChance != nil {
+		r.state.SpyLastChance = *update.SpyLastChance
+	}
+
+	if update.LastChanceSeconds != nil {
+		r.state.LastChanceSeconds = *update.LastChanceSeconds
+	}
+
+	if update.Rounds != nil {
+		r.state.Rounds = *update.Rounds
+	}
+
+	if update.AutoStart != nil {
+		r.state.AutoStart = *update.AutoStart
+	}
+
+	if update.AllowDuplicateRoles != nil {
+		r.state.AllowDuplicateRoles = *update.AllowDuplicateRoles
+	}
+
+	if update.TimeoutRule != nil {
+		r.state.TimeoutRule = *update.TimeoutRule
+	}
+
+	if update.FinalTurnSeconds != nil {
+		r.state.FinalTurnSeconds = *update.FinalTurnSeconds
+	}
+
+	if update.DeckID != nil {
+		r.state.DeckID = deck.ID
 		r.state.DeckName = deck.Name
 	}
 
@@ -216,12 +620,16 @@ func (r *Room) SetPlayerReady(userID uint, ready bool) error {
 		return fmt.Errorf("player not found")
 	}
 
+	if r.state.Status != StatusWaiting {
+		return fmt.Errorf("game already started")
+	}
+
 	player.IsReady = ready
 	r.saveToRedis()
 	r.broadcastState()
 
-	// Проверяем, все ли готовы (минимум 3 игрока)
-	if ready && len(r.state.Players) >= 3 {
+	// Проверяем, все ли готовы (минимум limits.MinPlayers игроков)
+	if ready && r.state.AutoStart && len(r.state.Players) >= r.limits.MinPlayers {
 		allReady := true
 		for _, p := range r.state.Players {
 			if !p.IsReady {
@@ -237,6 +645,64 @@ func (r *Room) SetPlayerReady(userID uint, ready bool) error {
 	return nil
 }
 
+// StartGame запускает игру вручную (только админ комнаты), например при выключенном AutoStart
+func (r *Room) StartGame(adminUserID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.state.HostID != adminUserID {
+		return fmt.Errorf("only room admin can start the game")
+	}
+
+	if r.state.Status != StatusWaiting {
+		return fmt.Errorf("game already started")
+	}
+
+	if len(r.state.Players) < r.limits.MinPlayers {
+		return fmt.Errorf("at least %d players required", r.limits.MinPlayers)
+	}
+
+	go r.startGame()
+
+	return nil
+}
+
+// Rematch возвращает завершенную комнату в ожидание с теми же игроками (только админ комнаты).
+// autoStart (если задан) включает или выключает автостарт, когда все снова будут готовы
+func (r *Room) Rematch(adminUserID uint, autoStart *bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.state.HostID != adminUserID {
+		return fmt.Errorf("only room admin can start a rematch")
+	}
+
+	if r.state.Status != StatusFinished {
+		return fmt.Errorf("game is not finished")
+	}
+
+	r.resetRound()
+	if autoStart != nil {
+		r.state.AutoStart = *autoStart
+	}
+
+	r.log.Info("Rematch in room %s started by %d", r.state.RoomID, adminUserID)
+
+	r.broadcastMessage(WSMessage{
+		Type: "rematch",
+		Payload: map[string]interface{}{
+			"room_id":    r.state.RoomID,
+			"host_id":    adminUserID,
+			"auto_start": r.state.AutoStart,
+		},
+	})
+
+	r.saveToRedis()
+	r.broadcastState()
+
+	return nil
+}
+
 // startGame начинает игру
 func (r *Room) startGame() {
 	r.mu.Lock()
@@ -258,18 +724,53 @@ func (r *Room) startGame() {
 		return
 	}
 
-	// Выбираем случайную локацию
-	rand.Seed(time.Now().UnixNano())
-	location := locations[rand.Intn(len(locations))]
-
-	// Сохраняем информацию о локации
-	r.state.Location = &LocationInfo{
-		Name:     location.Name,
-		ImageURL: location.ImageURL,
-		Roles:    []string(location.Roles),
+	// Новый матч: счет и сыгранные локации обнуляются
+	if r.state.Round == 0 || r.matchOver() {
+		r.state.Round = 0
+		r.state.Scores = make(map[uint]int)
+		r.state.UsedLocationIDs = nil
 	}
 
-	// Раздаем роли
+	// Выбираем случайную локацию, не повторяясь в пределах матча
+	candidates := make([]models.Location, 0, len(locations))
+	for _, l := range locations {
+		if !slices.Contains(r.state.UsedLocationIDs, l.ID) {
+			candidates = append(candidates, l)
+		}
+	}
+	if len(candidates) == 0 {
+		// Все локации колоды уже сыграны - начинаем круг заново
+		candidates = locations
+		r.state.UsedLocationIDs = nil
+	}
+
+	rand.Seed(time.Now().UnixNano())
+	location := candidates[rand.Intn(len(candidates))]
+	r.state.UsedLocationIDs = append(r.state.UsedLocationIDs, location.ID)
+
+	// Сохраняем информацию о локации
+	r.state.Location = &LocationInfo{
+		ID:           location.ID,
+		Name:         location.Name,
+		ImageURL:     location.ImageURL,
+		ThumbnailURL: location.ThumbnailURL,
+		Roles:        []string(location.Roles),
+	}
+
+	// Варианты для шпиона: все локации колоды, перемешанные, чтобы порядок не выдавал загаданную
+	r.state.DeckLocations = make([]LocationOption, 0, len(locations))
+	for _, l := range locations {
+		r.state.DeckLocations = append(r.state.DeckLocations, LocationOption{
+			ID:           l.ID,
+			Name:         l.Name,
+			ThumbnailURL: l.ThumbnailURL,
+		})
+	}
+	rand.Shuffle(len(r.state.DeckLocations), func(i, j int) {
+		r.state.DeckLocations[i], r.state.DeckLocations[j] = r.state.DeckLocations[j], r.state.DeckLocations[i]
+	})
+
+	// Раздаем роли
 	playerIDs := make([]uint, 0, len(r.state.Players))
 	for id := range r.state.Players {
 		playerIDs = append(playerIDs, id)
@@ -280,14 +781,8 @@ func (r *Room) startGame() {
 		playerIDs[i], playerIDs[j] = playerIDs[j], playerIDs[i]
 	})
 
-	// Назначаем шпионов
-	spyCount := r.state.SpyCount
-	if spyCount > len(playerIDs) {
-		spyCount = len(playerIDs) / 2 // Максимум половина
-	}
-	if spyCount == 0 {
-		spyCount = 1
-	}
+	// Назначаем шпионов ("Авто" определяется по числу игроков)
+	spyCount := r.limits.ResolveSpyCount(r.state.SpyCount, len(playerIDs))
 
 	r.state.SpyIDs = playerIDs[:spyCount]
 	spyMap := make(map[uint]bool)
@@ -295,32 +790,58 @@ func (r *Room) startGame() {
 		spyMap[id] = true
 	}
 
+	// Роли локации перемешиваются каждую игру, чтобы первые в списке не доставались чаще
+	roles := slices.Clone([]string(location.Roles))
+	rand.Shuffle(len(roles), func(i, j int) {
+		roles[i], roles[j] = roles[j], roles[i]
+	})
+	fallbackRole := r.fallbackRole(&location)
+
 	// Назначаем роли и локации
 	roleIndex := 0
 	for _, playerID := range playerIDs {
 		player := r.state.Players[playerID]
+		player.Eliminated = false
 		if spyMap[playerID] {
 			player.Role = RoleSpy
+			player.Location = ""
+			player.LocationRole = ""
 		} else {
 			player.Role = RoleLocal
 			player.Location = location.Name
-			if roleIndex < len(location.Roles) {
-				player.LocationRole = location.Roles[roleIndex]
-				roleIndex++
-			}
+			player.LocationRole = pickRole(roles, roleIndex, r.state.AllowDuplicateRoles, fallbackRole)
+			roleIndex++
 		}
 	}
 
-	// Устанавливаем таймер
-	duration := time.Duration(r.state.Duration) * time.Minute
-	timerEnd := time.Now().Add(duration)
-	r.state.TimerEnd = &timerEnd
 	r.state.Status = StatusPlaying
-
-	// Запускаем таймер
-	r.timer = time.AfterFunc(duration, func() {
-		r.handleTimerExpired()
-	})
+	r.state.StartedAt = time.Now()
+	r.state.Round++
+	r.state.Winner = ""
+	r.state.WinReason = ""
+	r.state.AccuserID = 0
+
+	// Устанавливаем таймер (в режиме без таймера игра идет до голосования или догадки шпиона)
+	r.state.TimerEnd = nil
+	r.state.Paused = false
+	r.state.RemainingTime = 0
+	r.state.Accusations = make(map[uint]int)
+	r.state.LastChance = nil
+	r.state.FinalAccusation = nil
+	r.state.LastVoteEndedAt = nil
+
+	// Первого спрашивающего выбираем случайно
+	r.state.QuestionHistory = nil
+	r.state.AskedByID = 0
+	r.state.CurrentAskerID = playerIDs[rand.Intn(len(playerIDs))]
+	if duration, timed := r.roundDuration(); timed {
+		timerEnd := time.Now().Add(duration)
+		r.state.TimerEnd = &timerEnd
+
+		r.timer = time.AfterFunc(duration, func() {
+			r.handleTimerExpired()
+		})
+	}
 
 	r.saveToRedis()
 
@@ -328,6 +849,273 @@ func (r *Room) startGame() {
 	r.sendRolesToPlayers()
 }
 
+// fallbackRole возвращает запасную роль локации, затем колоды, затем роль по умолчанию
+func (r *Room) fallbackRole(location *models.Location) string {
+	if location.FallbackRole != "" {
+		return location.FallbackRole
+	}
+
+	var deck models.Deck
+	if err := r.db.Select("fallback_role").First(&deck, r.state.DeckID).Error; err != nil {
+		r.log.Error("Failed to load deck %d fallback role: %v", r.state.DeckID, err)
+	} else if deck.FallbackRole != "" {
+		return deck.FallbackRole
+	}
+
+	return models.DefaultFallbackRole
+}
+
+// pickRole выдает index-ю роль из перемешанных; когда уникальные закончились -
+// повторную (allowDuplicates) или запасную
+func pickRole(roles []string, index int, allowDuplicates bool, fallback string) string {
+	if index < len(roles) {
+		return roles[index]
+	}
+
+	if allowDuplicates && len(roles) > 0 {
+		return roles[index%len(roles)]
+	}
+
+	return fallback
+}
+
+// PassQuestion фиксирует вопрос текущего спрашивающего игроку targetUserID, после чего спрашивает уже он.
+// Задать вопрос в ответ тому, кто только что спросил тебя, нельзя
+func (r *Room) PassQuestion(userID, targetUserID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.state.Status != StatusPlaying {
+		return fmt.Errorf("game is not in playing status")
+	}
+
+	if r.state.Paused {
+		return ErrGamePaused
+	}
+
+	if r.state.CurrentAskerID != userID {
+		return ErrNotYourQuestion
+	}
+
+	if userID == targetUserID {
+		return fmt.Errorf("cannot ask yourself")
+	}
+
+	target, exists := r.state.Players[targetUserID]
+	if !exists {
+		return fmt.Errorf("target player not found")
+	}
+
+	if target.Eliminated {
+		return fmt.Errorf("target player is eliminated")
+	}
+
+	// Правило действует, только если есть кого спросить, кроме спросившего
+	if targetUserID == r.state.AskedByID && r.activePlayers() > 2 {
+		return ErrNoAskingBack
+	}
+
+	r.state.QuestionHistory = append(r.state.QuestionHistory, QuestionTurn{
+		AskerID:  userID,
+		TargetID: targetUserID,
+		AskedAt:  time.Now(),
+	})
+	if len(r.state.QuestionHistory) > MaxQuestionHistory {
+		r.state.QuestionHistory = r.state.QuestionHistory[len(r.state.QuestionHistory)-MaxQuestionHistory:]
+	}
+
+	r.state.AskedByID = userID
+	r.state.CurrentAskerID = targetUserID
+
+	r.saveToRedis()
+	r.broadcastState()
+
+	return nil
+}
+
+// activePlayers возвращает количество игроков, не выбывших из игры
+func (r *Room) activePlayers() int {
+	count := 0
+	for _, p := range r.state.Players {
+		if !p.Eliminated {
+			count++
+		}
+	}
+	return count
+}
+
+// reassignAsker передает очередь вопроса случайному игроку, если спрашивающий выбыл или ушел
+func (r *Room) reassignAsker(userID uint) {
+	if r.state.CurrentAskerID != userID {
+		if r.state.AskedByID == userID {
+			r.state.AskedByID = 0
+		}
+		return
+	}
+
+	candidates := make([]uint, 0, len(r.state.Players))
+	for id, p := range r.state.Players {
+		if id != userID && !p.Eliminated {
+			candidates = append(candidates, id)
+		}
+	}
+
+	r.state.AskedByID = 0
+	r.state.CurrentAskerID = 0
+	if len(candidates) > 0 {
+		r.state.CurrentAskerID = candidates[rand.Intn(len(candidates))]
+	}
+}
+
+// roundDuration возвращает длительность раунда согласно режиму таймера
+func (r *Room) roundDuration() (time.Duration, bool) {
+	switch r.state.TimerMode {
+	case TimerUntimed:
+		return 0, false
+	case TimerPerPlayer:
+		return time.Duration(r.state.PerPlayerSeconds*len(r.state.Players)) * time.Second, true
+	default:
+		return time.Duration(r.state.Duration) * time.Minute, true
+	}
+}
+
+// Pause ставит игру на паузу, сохраняя оставшееся время (только админ комнаты)
+func (r *Room) Pause(adminUserID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.state.HostID != adminUserID {
+		return fmt.Errorf("only room admin can pause the game")
+	}
+
+	if r.state.Status != StatusPlaying && r.state.Status != StatusVoting {
+		return fmt.Errorf("game is not running")
+	}
+
+	if r.state.TimerMode == TimerUntimed {
+		return fmt.Errorf("game has no timer")
+	}
+
+	if r.state.FinalAccusation != nil {
+		return fmt.Errorf("cannot pause during final accusation")
+	}
+
+	if r.state.Paused {
+		return fmt.Errorf("game already paused")
+	}
+
+	r.state.Paused = true
+	r.updateClock()
+	r.updateVoteClock()
+
+	r.log.Info("Room %s paused by %d, %s remaining", r.state.RoomID, adminUserID, r.state.RemainingTime)
+
+	r.broadcastMessage(WSMessage{
+		Type: "game_paused",
+		Payload: map[string]interface{}{
+			"room_id":           r.state.RoomID,
+			"paused_by":         adminUserID,
+			"remaining_seconds": int(r.state.RemainingTime.Seconds()),
+		},
+	})
+
+	r.saveToRedis()
+	r.broadcastState()
+
+	return nil
+}
+
+// Resume снимает игру с паузы и перезапускает таймер с оставшимся временем (только админ комнаты)
+func (r *Room) Resume(adminUserID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.state.HostID != adminUserID {
+		return fmt.Errorf("only room admin can resume the game")
+	}
+
+	if r.state.Status != StatusPlaying && r.state.Status != StatusVoting {
+		return fmt.Errorf("game is not running")
+	}
+
+	if !r.state.Paused {
+		return fmt.Errorf("game is not paused")
+	}
+
+	r.state.Paused = false
+	r.updateClock()
+	r.updateVoteClock()
+
+	r.log.Info("Room %s resumed by %d", r.state.RoomID, adminUserID)
+
+	payload := map[string]interface{}{
+		"room_id":    r.state.RoomID,
+		"resumed_by": adminUserID,
+	}
+
+	// При заморозке на время голосования таймер пойдет только после него
+	if r.state.TimerEnd != nil {
+		payload["timer_end"] = r.state.TimerEnd.Unix()
+	}
+
+	if r.state.Status == StatusVoting {
+		payload["vote_deadline"] = r.state.Voting.Deadline.Unix()
+	}
+
+	r.broadcastMessage(WSMessage{
+		Type:    "game_resumed",
+		Payload: payload,
+	})
+
+	r.saveToRedis()
+	r.broadcastState()
+
+	return nil
+}
+
+// updateClock останавливает или запускает таймер раунда в зависимости от паузы и голосования
+func (r *Room) updateClock() {
+	running := r.state.TimerEnd != nil
+	frozen := r.state.RemainingTime > 0
+	if !running && !frozen {
+		// Таймера нет (режим без таймера)
+		return
+	}
+
+	shouldRun := !r.state.Paused && !(r.state.FreezeOnVote && r.state.Status == StatusVoting)
+
+	switch {
+	case running && !shouldRun:
+		if r.timer != nil {
+			r.timer.Stop()
+		}
+
+		// Не меньше 1мс, чтобы отличать остановленный таймер от его отсутствия
+		remaining := time.Until(*r.state.TimerEnd)
+		if remaining < time.Millisecond {
+			remaining = time.Millisecond
+		}
+
+		r.state.RemainingTime = remaining
+		r.state.TimerEnd = nil
+
+	case running && shouldRun && !time.Now().Before(*r.state.TimerEnd):
+		// Время вышло, пока шло голосование без заморозки: срабатывание тогда было пропущено
+		r.timer = time.AfterFunc(0, func() {
+			r.handleTimerExpired()
+		})
+
+	case frozen && shouldRun:
+		timerEnd := time.Now().Add(r.state.RemainingTime)
+		r.state.TimerEnd = &timerEnd
+		r.state.RemainingTime = 0
+
+		r.timer = time.AfterFunc(time.Until(timerEnd), func() {
+			r.handleTimerExpired()
+		})
+	}
+}
+
 // sendRolesToPlayers отправляет каждому игроку его роль
 func (r *Room) sendRolesToPlayers() {
 	for userID, player := range r.state.Players {
@@ -336,31 +1124,62 @@ func (r *Room) sendRolesToPlayers() {
 			continue
 		}
 
-		// Создаем персональное сообщение
-		personalState := map[string]interface{}{
-			"role": player.Role,
-		}
+		client.SendMessage(r.gameStartedMessage(player))
+	}
 
-		if player.Role == RoleLocal {
-			personalState["location"] = player.Location
-			personalState["location_role"] = player.LocationRole
-		}
+	// Отправляем общее обновление состояния
+	r.broadcastState()
+}
 
-		msg := WSMessage{
-			Type: "game_started",
-			Payload: map[string]interface{}{
-				"room_id":   r.state.RoomID,
-				"my_role":   personalState,
-				"timer_end": r.state.TimerEnd.Unix(),
-				"spy_count": len(r.state.SpyIDs),
-			},
+// gameStartedMessage формирует персональное сообщение game_started с ролью игрока
+func (r *Room) gameStartedMessage(player *Player) WSMessage {
+	personalState := map[string]interface{}{
+		"role": player.Role,
+	}
+
+	if player.Role == RoleLocal {
+		personalState["location"] = player.Location
+		personalState["location_role"] = player.LocationRole
+		personalState["location_image_url"] = r.state.Location.ImageURL
+	} else {
+		// Шпион выбирает догадку из списка локаций колоды
+		personalState["locations"] = r.state.DeckLocations
+
+		if r.state.RevealSpyPartners && len(r.state.SpyIDs) > 1 {
+			personalState["partners"] = r.spyPartners(player.UserID)
 		}
+	}
 
-		client.SendMessage(msg)
+	if player.Eliminated {
+		personalState["eliminated"] = true
 	}
 
-	// Отправляем общее обновление состояния
-	r.broadcastState()
+	payload := map[string]interface{}{
+		"room_id":    r.state.RoomID,
+		"my_role":    personalState,
+		"spy_count":  len(r.state.SpyIDs),
+		"timer_mode": r.state.TimerMode,
+		"round":      r.state.Round,
+		"rounds":     r.state.Rounds,
+	}
+
+	// В режиме без таймера и на паузе timer_end не передается
+	if r.state.TimerEnd != nil {
+		payload["timer_end"] = r.state.TimerEnd.Unix()
+	}
+
+	if r.state.Paused {
+		payload["paused"] = true
+	}
+
+	if r.state.RemainingTime > 0 {
+		payload["remaining_seconds"] = int(r.state.RemainingTime.Seconds())
+	}
+
+	return WSMessage{
+		Type:    "game_started",
+		Payload: payload,
+	}
 }
 
 // handleTimerExpired обрабатывает истечение таймера
@@ -368,14 +1187,19 @@ func (r *Room) handleTimerExpired() {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if r.state.Status != StatusPlaying {
+	// Таймер был остановлен (пауза или голосование), пока срабатывание ждало блокировку
+	if r.state.Status != StatusPlaying || r.state.TimerEnd == nil || time.Now().Before(*r.state.TimerEnd) {
+		return
+	}
+
+	// Вместо автоматической победы шпиона - финальный раунд обвинений
+	if r.state.TimeoutRule == TimeoutFinalAccusation {
+		r.startFinalAccusation()
 		return
 	}
 
 	// Победа шпиона (таймер истек)
-	r.state.Winner = "spy"
-	r.state.Status = StatusFinished
-	r.finishGame()
+	r.finishGame("spy", WinTimeout)
 }
 
 // StartVoting начинает голосование
@@ -383,21 +1207,77 @@ func (r *Room) StartVoting(initiatorID, targetUserID uint) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if r.state.Status != StatusPlaying {
+	// В финальном раунде обвинение выдвигает игрок, чей сейчас ход
+	finalRound := r.state.Status == StatusFinalAccusation
+	if !finalRound && r.state.Status != StatusPlaying {
 		return fmt.Errorf("game is not in playing status")
 	}
 
+	if r.state.Paused {
+		return ErrGamePaused
+	}
+
+	initiator, exists := r.state.Players[initiatorID]
+	if !exists {
+		return ErrPlayerNotFound
+	}
+
+	if initiator.Eliminated {
+		return ErrPlayerEliminated
+	}
+
+	if initiatorID == targetUserID {
+		return ErrSelfAccusation
+	}
+
 	// Проверяем, что цель существует
-	if _, exists := r.state.Players[targetUserID]; !exists {
+	target, exists := r.state.Players[targetUserID]
+	if !exists {
 		return fmt.Errorf("target player not found")
 	}
 
+	if target.Eliminated {
+		return fmt.Errorf("target player is already eliminated")
+	}
+
+	if finalRound {
+		// Лимиты обвинений и пауза между голосованиями в финальном раунде не действуют
+		if r.currentFinalTurn() != initiatorID {
+			return fmt.Errorf("not your turn")
+		}
+		r.stopTurnTimer()
+	} else {
+		if r.state.AccusationsPerPlayer != AccusationsUnlimited && r.state.Accusations[initiatorID] >= r.state.AccusationsPerPlayer {
+			return ErrAccusationLimit
+		}
+
+		// Пауза между голосованиями
+		if r.state.LastVoteEndedAt != nil {
+			cooldownEnd := r.state.LastVoteEndedAt.Add(time.Duration(r.state.VoteCooldown) * time.Second)
+			if wait := time.Until(cooldownEnd); wait > 0 {
+				return &GameError{Message: fmt.Sprintf("next vote available in %d seconds", int(wait.Round(time.Second).Seconds()))}
+			}
+		}
+
+		if r.state.Accusations == nil {
+			r.state.Accusations = make(map[uint]int)
+		}
+		r.state.Accusations[initiatorID]++
+	}
+
 	// Сбрасываем предыдущее голосование
-	r.state.Voting = &VotingState{
+	now := time.Now()
+	voting := &VotingState{
+		InitiatorID:  initiatorID,
 		TargetUserID: targetUserID,
-		Votes:        make(map[uint]bool),
-		StartedAt:    time.Now(),
+		Votes:        make(map[uint]VoteChoice),
+		StartedAt:    now,
+		Deadline:     now.Add(time.Duration(r.state.VoteTimeout) * time.Second),
 	}
+	r.state.Voting = voting
+
+	// По дедлайну недостающие голоса учитываются согласно MissingVotePolicy
+	r.armVoteTimer(voting)
 
 	// Сбрасываем флаги голосования
 	for _, player := range r.state.Players {
@@ -405,6 +1285,7 @@ func (r *Room) StartVoting(initiatorID, targetUserID uint) error {
 	}
 
 	r.state.Status = StatusVoting
+	r.updateClock()
 	r.saveToRedis()
 
 	// Отправляем уведомление о голосовании
@@ -413,6 +1294,7 @@ func (r *Room) StartVoting(initiatorID, targetUserID uint) error {
 		Payload: map[string]interface{}{
 			"target_user_id": targetUserID,
 			"initiator_id":   initiatorID,
+			"deadline":       voting.Deadline.Unix(),
 		},
 	}
 	r.broadcastMessage(msg)
@@ -420,8 +1302,8 @@ func (r *Room) StartVoting(initiatorID, targetUserID uint) error {
 	return nil
 }
 
-// Vote обрабатывает голос игрока
-func (r *Room) Vote(userID uint, vote bool) error {
+// Vote обрабатывает голос игрока (за, против или воздержался)
+func (r *Room) Vote(userID uint, vote VoteChoice) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -433,6 +1315,10 @@ func (r *Room) Vote(userID uint, vote bool) error {
 		return fmt.Errorf("voting not initialized")
 	}
 
+	if r.state.Paused {
+		return ErrGamePaused
+	}
+
 	// Нельзя голосовать за себя
 	if userID == r.state.Voting.TargetUserID {
 		return fmt.Errorf("cannot vote for yourself")
@@ -443,10 +1329,20 @@ func (r *Room) Vote(userID uint, vote bool) error {
 		return fmt.Errorf("player not found")
 	}
 
+	if player.Eliminated {
+		return ErrPlayerEliminated
+	}
+
 	if player.IsVoted {
 		return fmt.Errorf("already voted")
 	}
 
+	switch vote {
+	case VoteFor, VoteAgainst, VoteAbstain:
+	default:
+		return fmt.Errorf("invalid vote")
+	}
+
 	// Записываем голос
 	player.IsVoted = true
 	player.Vote = vote
@@ -456,65 +1352,366 @@ func (r *Room) Vote(userID uint, vote bool) error {
 	r.broadcastState()
 
 	// Проверяем, все ли проголосовали
-	allVoted := true
+	r.checkVotingComplete()
+
+	return nil
+}
+
+// checkVotingComplete подводит итог, если проголосовали все подключенные игроки (кроме обвиняемого)
+func (r *Room) checkVotingComplete() {
 	for id, p := range r.state.Players {
-		if id != r.state.Voting.TargetUserID && !p.IsVoted {
-			allVoted = false
-			break
+		if id == r.state.Voting.TargetUserID || !p.IsConnected || p.Eliminated {
+			continue
+		}
+		if _, voted := r.state.Voting.Votes[id]; !voted {
+			return
 		}
 	}
 
-	if allVoted {
-		r.processVotingResult()
+	r.processVotingResult(false)
+}
+
+// handleVoteTimeout подводит итог голосования по дедлайну
+func (r *Room) handleVoteTimeout(voting *VotingState) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Голосование уже завершилось, начато новое или поставлено на паузу
+	if r.state.Status != StatusVoting || r.state.Voting != voting || voting.Remaining > 0 {
+		return
 	}
 
-	return nil
+	r.log.Info("Voting in room %s timed out", r.state.RoomID)
+	r.processVotingResult(true)
 }
 
-// processVotingResult обрабатывает результат голосования
-func (r *Room) processVotingResult() {
-	// Подсчитываем голоса "за"
-	votesFor := 0
-	for _, vote := range r.state.Voting.Votes {
-		if vote {
-			votesFor++
+// cancelVoting отменяет голосование и возвращает игру
+func (r *Room) cancelVoting() {
+	r.stopVoteTimer()
+
+	now := time.Now()
+	r.state.LastVoteEndedAt = &now
+	r.state.Voting = nil
+
+	// В финальном раунде отклоненное обвинение передает ход следующему
+	if r.state.FinalAccusation != nil {
+		r.state.Status = StatusFinalAccusation
+		r.nextFinalTurn()
+		return
+	}
+
+	r.state.Status = StatusPlaying
+	r.updateClock()
+}
+
+// armVoteTimer запускает дедлайн голосования
+func (r *Room) armVoteTimer(voting *VotingState) {
+	r.voteTimer = time.AfterFunc(time.Until(voting.Deadline), func() {
+		r.handleVoteTimeout(voting)
+	})
+}
+
+// updateVoteClock останавливает дедлайн голосования на паузе и после нее запускает с остатком
+func (r *Room) updateVoteClock() {
+	voting := r.state.Voting
+	if r.state.Status != StatusVoting || voting == nil {
+		return
+	}
+
+	switch {
+	case r.state.Paused && voting.Remaining == 0:
+		r.stopVoteTimer()
+		voting.Remaining = max(time.Until(voting.Deadline), time.Millisecond)
+
+	case !r.state.Paused && voting.Remaining > 0:
+		voting.Deadline = time.Now().Add(voting.Remaining)
+		voting.Remaining = 0
+		r.armVoteTimer(voting)
+	}
+}
+
+// stopVoteTimer останавливает дедлайн голосования
+func (r *Room) stopVoteTimer() {
+	if r.voteTimer != nil {
+		r.voteTimer.Stop()
+		r.voteTimer = nil
+	}
+}
+
+// tallyVotes подсчитывает голоса согласно MissingVotePolicy.
+// Отключенные игроки, не успевшие проголосовать, не учитываются.
+func (r *Room) tallyVotes(timedOut bool) VoteTally {
+	tally := VoteTally{
+		TargetUserID: r.state.Voting.TargetUserID,
+		Policy:       r.state.MissingVotePolicy,
+		TimedOut:     timedOut,
+	}
+
+	for id, p := range r.state.Players {
+		if id == r.state.Voting.TargetUserID || p.Eliminated {
+			continue
+		}
+
+		vote, voted := r.state.Voting.Votes[id]
+		switch {
+		case !voted && p.IsConnected:
+			tally.Missing++
+		case !voted:
+			continue
+		case vote == VoteFor:
+			tally.For++
+		case vote == VoteAgainst:
+			tally.Against++
+		default:
+			tally.Abstained++
 		}
 	}
 
-	// Нужно единогласие (все кроме обвиняемого)
-	requiredVotes := len(r.state.Players) - 1
+	// Нужно единогласие: ни одного голоса "против"
+	if r.state.MissingVotePolicy == MissingVoteQuorum {
+		// Воздержавшиеся входят в кворум, но не влияют на решение
+		eligible := tally.For + tally.Against + tally.Abstained + tally.Missing
+		quorum := eligible/2 + 1
+		tally.Passed = tally.For > 0 && tally.Against == 0 &&
+			tally.For+tally.Abstained >= quorum
+	} else {
+		// Воздержавшиеся и не проголосовавшие считаются голосами "против"
+		tally.Passed = tally.For > 0 && tally.Against == 0 &&
+			tally.Abstained == 0 && tally.Missing == 0
+	}
+
+	return tally
+}
 
-	if votesFor == requiredVotes {
-		// Единогласное голосование - проверяем роль
+// processVotingResult обрабатывает результат голосования
+func (r *Room) processVotingResult(timedOut bool) {
+	r.stopVoteTimer()
+
+	tally := r.tallyVotes(timedOut)
+
+	// Итог голосования объявляется до продолжения или завершения игры
+	r.broadcastMessage(WSMessage{
+		Type:    "vote_result",
+		Payload: tally,
+	})
+
+	if tally.Passed {
+		// Обвинение принято - проверяем роль
 		targetPlayer := r.state.Players[r.state.Voting.TargetUserID]
 		if targetPlayer.Role == RoleSpy {
-			// Победа местных
-			r.state.Winner = "locals"
-			r.state.Status = StatusFinished
-			r.finishGame()
+			r.state.AccuserID = r.state.Voting.InitiatorID
+
+			// Пойманный шпион выбывает, если остались другие
+			if r.eliminateSpy(targetPlayer.UserID, "voted_out") {
+				r.cancelVoting()
+				r.saveToRedis()
+				r.broadcastState()
+				return
+			}
+
+			// Последний пойманный шпион может забрать победу, угадав локацию
+			if r.state.SpyLastChance {
+				r.startLastChance(targetPlayer.UserID)
+				return
+			}
+
+			// Пойманы все шпионы - победа местных
+			r.finishGame("locals", WinSpyCaught)
 		} else {
 			// Ошиблись - победа шпиона
-			r.state.Winner = "spy"
-			r.state.Status = StatusFinished
-			r.finishGame()
+			r.finishGame("spy", WinWrongAccusation)
 		}
 	} else {
-		// Не единогласие - продолжаем игру
-		r.state.Voting = nil
-		r.state.Status = StatusPlaying
+		// Обвинение отклонено - продолжаем игру
+		r.cancelVoting()
+		r.saveToRedis()
 		r.broadcastState()
 	}
 }
 
-// SpyGuess обрабатывает попытку шпиона угадать локацию
-func (r *Room) SpyGuess(userID uint, locationName string) error {
+// startFinalAccusation начинает финальный раунд: игроки по очереди (в порядке входа в комнату)
+// выдвигают обвинение, которое ставится на голосование. Если никого не осудили - побеждает шпион
+func (r *Room) startFinalAccusation() {
+	// Таймер раунда больше не нужен
+	r.state.TimerEnd = nil
+	r.state.RemainingTime = 0
+
+	players := make([]*Player, 0, len(r.state.Players))
+	for _, p := range r.state.Players {
+		if !p.Eliminated {
+			players = append(players, p)
+		}
+	}
+	sort.Slice(players, func(i, j int) bool {
+		return players[i].JoinedAt.Before(players[j].JoinedAt)
+	})
+
+	order := make([]uint, 0, len(players))
+	for _, p := range players {
+		order = append(order, p.UserID)
+	}
+
+	r.state.FinalAccusation = &FinalAccusationState{
+		Order: order,
+		Turn:  -1,
+	}
+	r.state.Status = StatusFinalAccusation
+
+	r.log.Info("Time is up in room %s, final accusation round started", r.state.RoomID)
+
+	r.broadcastMessage(WSMessage{
+		Type: "final_accusation_started",
+		Payload: map[string]interface{}{
+			"room_id": r.state.RoomID,
+			"order":   order,
+		},
+	})
+
+	r.nextFinalTurn()
+}
+
+// nextFinalTurn передает ход следующему игроку финального раунда или завершает игру победой шпиона
+func (r *Room) nextFinalTurn() {
+	r.stopTurnTimer()
+
+	fa := r.state.FinalAccusation
+	for {
+		fa.Turn++
+		if fa.Turn >= len(fa.Order) {
+			// Очередь закончилась, никого не осудили
+			r.finishGame("spy", WinTimeout)
+			return
+		}
+
+		// Ушедших и выбывших игроков пропускаем
+		if p, exists := r.state.Players[fa.Order[fa.Turn]]; exists && !p.Eliminated {
+			break
+		}
+	}
+
+	fa.TurnDeadline = time.Now().Add(time.Duration(r.state.FinalTurnSeconds) * time.Second)
+
+	turn := fa.Turn
+	r.turnTimer = time.AfterFunc(time.Until(fa.TurnDeadline), func() {
+		r.handleFinalTurnExpired(fa, turn)
+	})
+
+	r.broadcastMessage(WSMessage{
+		Type: "final_turn",
+		Payload: map[string]interface{}{
+			"room_id":  r.state.RoomID,
+			"user_id":  fa.Order[turn],
+			"turn":     turn,
+			"deadline": fa.TurnDeadline.Unix(),
+		},
+	})
+
+	r.saveToRedis()
+	r.broadcastState()
+}
+
+// handleFinalTurnExpired пропускает ход игрока, не выдвинувшего обвинение вовремя
+func (r *Room) handleFinalTurnExpired(fa *FinalAccusationState, turn int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.state.Status != StatusFinalAccusation || r.state.FinalAccusation != fa || fa.Turn != turn {
+		return
+	}
+
+	r.nextFinalTurn()
+}
+
+// currentFinalTurn возвращает ID игрока, чей ход в финальном раунде (0 - финального раунда нет)
+func (r *Room) currentFinalTurn() uint {
+	fa := r.state.FinalAccusation
+	if fa == nil || fa.Turn < 0 || fa.Turn >= len(fa.Order) {
+		return 0
+	}
+	return fa.Order[fa.Turn]
+}
+
+// stopTurnTimer останавливает таймер хода финального раунда
+func (r *Room) stopTurnTimer() {
+	if r.turnTimer != nil {
+		r.turnTimer.Stop()
+		r.turnTimer = nil
+	}
+}
+
+// startLastChance дает пойманному шпиону LastChanceSeconds на финальную догадку
+func (r *Room) startLastChance(spyID uint) {
+	if r.timer != nil {
+		r.timer.Stop()
+	}
+	r.stopVoteTimer()
+
+	lastChance := &LastChanceState{
+		SpyID:    spyID,
+		Deadline: time.Now().Add(time.Duration(r.state.LastChanceSeconds) * time.Second),
+	}
+
+	r.state.Voting = nil
+	r.state.LastChance = lastChance
+	r.state.Status = StatusLastChance
+
+	r.lastChanceTimer = time.AfterFunc(time.Until(lastChance.Deadline), func() {
+		r.handleLastChanceExpired(lastChance)
+	})
+
+	r.log.Info("Spy %d caught in room %s, last chance until %s", spyID, r.state.RoomID, lastChance.Deadline)
+
+	r.broadcastMessage(WSMessage{
+		Type: "last_chance",
+		Payload: map[string]interface{}{
+			"room_id":  r.state.RoomID,
+			"spy_id":   spyID,
+			"deadline": lastChance.Deadline.Unix(),
+		},
+	})
+
+	r.saveToRedis()
+	r.broadcastState()
+}
+
+// handleLastChanceExpired завершает игру победой местных, если шпион не успел угадать
+func (r *Room) handleLastChanceExpired(lastChance *LastChanceState) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if r.state.Status != StatusPlaying {
+	if r.state.Status != StatusLastChance || r.state.LastChance != lastChance {
+		return
+	}
+
+	r.finishGame("locals", WinSpyCaught)
+}
+
+// stopPhaseTimers останавливает таймеры голосования, финального раунда и последнего шанса
+func (r *Room) stopPhaseTimers() {
+	r.stopVoteTimer()
+	r.stopTurnTimer()
+
+	if r.lastChanceTimer != nil {
+		r.lastChanceTimer.Stop()
+		r.lastChanceTimer = nil
+	}
+}
+
+// SpyGuess обрабатывает попытку шпиона угадать локацию (по ID локации колоды)
+func (r *Room) SpyGuess(userID uint, locationID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// В фазе последнего шанса угадывает только пойманный шпион
+	lastChance := r.state.Status == StatusLastChance
+	if !lastChance && r.state.Status != StatusPlaying {
 		return fmt.Errorf("game is not in playing status")
 	}
 
+	if r.state.Paused {
+		return ErrGamePaused
+	}
+
 	player, exists := r.state.Players[userID]
 	if !exists {
 		return fmt.Errorf("player not found")
@@ -524,95 +1721,268 @@ func (r *Room) SpyGuess(userID uint, locationName string) error {
 		return fmt.Errorf("only spy can guess location")
 	}
 
+	if lastChance && r.state.LastChance.SpyID != userID {
+		return fmt.Errorf("only the caught spy can guess now")
+	}
+
+	if player.Eliminated {
+		return ErrPlayerEliminated
+	}
+
+	if !r.isDeckLocation(locationID) {
+		return fmt.Errorf("location is not in this deck")
+	}
+
 	// Проверяем угадал ли
-	guessed := locationName == r.state.Location.Name
+	guessed := locationID == r.state.Location.ID
 
 	if guessed {
-		r.state.Winner = "spy"
-	} else {
-		r.state.Winner = "locals"
+		r.finishGame("spy", WinSpyGuessed)
+		return nil
 	}
 
-	r.state.Status = StatusFinished
-	r.finishGame()
+	// Ошибившийся шпион выбывает, остальные продолжают угадывать
+	if !lastChance && r.eliminateSpy(userID, "wrong_guess") {
+		r.saveToRedis()
+		r.broadcastState()
+		return nil
+	}
+
+	// Промах в последнем шансе - шпиона все равно поймали голосованием
+	if lastChance {
+		r.finishGame("locals", WinSpyCaught)
+	} else {
+		r.finishGame("locals", WinWrongGuess)
+	}
 
 	return nil
 }
 
-// finishGame завершает игру
-func (r *Room) finishGame() {
+// isWinner проверяет, выиграл ли игрок. Выбывший шпион проигрывает, даже если победили шпионы
+func (r *Room) isWinner(player *Player) bool {
+	if player.Role == RoleSpy {
+		return r.state.Winner == "spy" && !player.Eliminated
+	}
+	return r.state.Winner == "locals"
+}
+
+// winnerIDs возвращает ID победивших игроков
+func (r *Room) winnerIDs() []uint {
+	ids := make([]uint, 0, len(r.state.Players))
+	for id, player := range r.state.Players {
+		if r.isWinner(player) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// isDeckLocation проверяет, что локация входит в колоду текущей игры
+func (r *Room) isDeckLocation(locationID uint) bool {
+	for _, l := range r.state.DeckLocations {
+		if l.ID == locationID {
+			return true
+		}
+	}
+	return false
+}
+
+// finishGame завершает раунд победой winner, начисляет очки и, если матч не окончен,
+// возвращает комнату в ожидание следующего раунда
+func (r *Room) finishGame(winner string, reason WinReason) {
 	if r.timer != nil {
 		r.timer.Stop()
 	}
+	r.stopPhaseTimers()
+
+	r.state.Winner = winner
+	r.state.WinReason = reason
+	r.state.Status = StatusFinished
+	r.state.Paused = false
+	r.state.RemainingTime = 0
+
+	points := r.roundPoints()
+	r.addScores(points)
 
 	// Сохраняем в GameHistory
-	go r.saveGameHistory()
+	r.saveGameHistory()
 
 	// Отправляем результаты
 	msg := WSMessage{
 		Type: "game_over",
 		Payload: map[string]interface{}{
-			"winner":   r.state.Winner,
+			"winner":       r.state.Winner,
+			"win_reason":   r.state.WinReason,
+			"winner_ids":   r.winnerIDs(),
+			"spy_ids":      r.state.SpyIDs,
+			"location":     r.state.Location,
+			"round":        r.state.Round,
+			"rounds":       r.state.Rounds,
+			"round_points": points,
+			"scores":       r.state.Scores,
+			"match_over":   r.matchOver(),
+		},
+	}
+	r.broadcastMessage(msg)
+
+	if !r.matchOver() {
+		r.resetRound()
+		r.broadcastState()
+	}
+
+	r.saveToRedis()
+}
+
+// abortGame завершает игру без победителя (например, шпион покинул игру).
+// В матче прерванный раунд не засчитывается и будет сыгран заново
+func (r *Room) abortGame(reason string) {
+	if r.timer != nil {
+		r.timer.Stop()
+	}
+	r.stopPhaseTimers()
+
+	r.state.Voting = nil
+	r.state.LastChance = nil
+	r.state.FinalAccusation = nil
+	r.state.Winner = ""
+	r.state.Status = StatusFinished
+	r.state.Paused = false
+	r.state.RemainingTime = 0
+
+	r.broadcastMessage(WSMessage{
+		Type: "game_over",
+		Payload: map[string]interface{}{
+			"winner":   "",
+			"reason":   reason,
 			"spy_ids":  r.state.SpyIDs,
 			"location": r.state.Location,
+			"round":    r.state.Round,
+			"rounds":   r.state.Rounds,
+			"scores":   r.state.Scores,
 		},
+	})
+
+	if r.state.Rounds > 1 {
+		r.state.Round--
+		r.resetRound()
 	}
-	r.broadcastMessage(msg)
 
 	r.saveToRedis()
+	r.broadcastState()
 }
 
-// saveGameHistory сохраняет историю игры в БД
-func (r *Room) saveGameHistory() {
-	duration := int(time.Since(r.state.CreatedAt).Seconds())
+// resetRound возвращает комнату в ожидание: игроки и их места сохраняются,
+// роли, готовность и состояние раунда сбрасываются. Счет матча не трогается
+func (r *Room) resetRound() {
+	for _, player := range r.state.Players {
+		player.Role = ""
+		player.Location = ""
+		player.LocationRole = ""
+		player.IsReady = false
+		player.Eliminated = false
+		player.IsVoted = false
+		player.Vote = ""
+	}
+
+	r.state.Status = StatusWaiting
+	r.state.Location = nil
+	r.state.DeckLocations = nil
+	r.state.SpyIDs = nil
+	r.state.TimerEnd = nil
+	r.state.Voting = nil
+	r.state.LastChance = nil
+	r.state.FinalAccusation = nil
+	r.state.Winner = ""
+	r.state.WinReason = ""
+	r.state.AccuserID = 0
+	r.state.Paused = false
+	r.state.RemainingTime = 0
+	r.state.Accusations = nil
+	r.state.LastVoteEndedAt = nil
+	r.state.CurrentAskerID = 0
+	r.state.AskedByID = 0
+	r.state.QuestionHistory = nil
+
+	r.endShortMatch()
+}
+
+// endShortMatch сбрасывает матч, если между раундами в комнате осталось меньше MinPlayers:
+// следующий раунд не запустить, а новых игроков посреди матча не пускают
+func (r *Room) endShortMatch() {
+	if r.state.Status != StatusWaiting || !r.midMatch() || len(r.state.Players) >= r.limits.MinPlayers {
+		return
+	}
+
+	r.log.Info("Room %s: match ended after round %d, not enough players", r.state.RoomID, r.state.Round)
 
+	r.state.Round = 0
+	r.state.Scores = nil
+	r.state.UsedLocationIDs = nil
+}
+
+// playerResult итог раунда для статистики игрока
+type playerResult struct {
+	userID uint
+	role   PlayerRole
+	won    bool
+}
+
+// saveGameHistory сохраняет историю игры в БД. Итоги снимаются сразу,
+// а запись идет в фоне: к этому моменту комната может уже перейти к следующему раунду
+func (r *Room) saveGameHistory() {
 	history := models.GameHistory{
-		RoomUUID: r.state.RoomID,
-		DeckName: r.state.DeckName,
-		Winner:   r.state.Winner,
-		Duration: duration,
+		RoomUUID:  r.state.RoomID,
+		DeckName:  r.state.DeckName,
+		Winner:    r.state.Winner,
+		Duration:  int(time.Since(r.state.StartedAt).Seconds()),
+		TimerMode: string(r.state.TimerMode),
 	}
 
-	// Получаем пользователей
-	var userIDs []uint
-	for id := range r.state.Players {
+	userIDs := make([]uint, 0, len(r.state.Players))
+	results := make([]playerResult, 0, len(r.state.Players))
+	for id, player := range r.state.Players {
 		userIDs = append(userIDs, id)
+		results = append(results, playerResult{
+			userID: id,
+			role:   player.Role,
+			won:    r.isWinner(player),
+		})
 	}
 
-	var users []models.User
-	r.db.Where("id IN ?", userIDs).Find(&users)
-	history.Players = users
+	go func() {
+		// Получаем пользователей
+		var users []models.User
+		r.db.Where("id IN ?", userIDs).Find(&users)
+		history.Players = users
 
-	r.db.Create(&history)
+		r.db.Create(&history)
 
-	// Обновляем статистику пользователей
-	for _, player := range r.state.Players {
-		var user models.User
-		if err := r.db.First(&user, player.UserID).Error; err != nil {
-			continue
-		}
-
-		user.GamesPlayed++
+		// Обновляем статистику пользователей
+		for _, result := range results {
+			var user models.User
+			if err := r.db.First(&user, result.userID).Error; err != nil {
+				continue
+			}
 
-		isWinner := (r.state.Winner == "spy" && player.Role == RoleSpy) ||
-			(r.state.Winner == "locals" && player.Role == RoleLocal)
+			user.GamesPlayed++
 
-		if isWinner {
-			if player.Role == RoleSpy {
-				user.WinsSpy++
+			if result.won {
+				if result.role == RoleSpy {
+					user.WinsSpy++
+				} else {
+					user.WinsLocal++
+				}
 			} else {
-				user.WinsLocal++
+				if result.role == RoleSpy {
+					user.LossesSpy++
+				} else {
+					user.LossesLocal++
+				}
 			}
-		} else {
-			if player.Role == RoleSpy {
-				user.LossesSpy++
-			} else {
-				user.LossesLocal++
-			}
-		}
 
-		r.db.Save(&user)
-	}
+			r.db.Save(&user)
+		}
+	}()
 }
 
 // broadcastState отправляет текущее состояние всем клиентам
@@ -637,38 +2007,111 @@ func (r *Room) getPublicState() map[string]interface{} {
 	players := make([]map[string]interface{}, 0, len(r.state.Players))
 	for _, player := range r.state.Players {
 		p := map[string]interface{}{
-			"user_id":    player.UserID,
-			"tg_id":      player.TgID,
-			"username":   player.Username,
-			"avatar_url": player.AvatarURL,
-			"is_ready":   player.IsReady,
+			"user_id":      player.UserID,
+			"tg_id":        player.TgID,
+			"username":     player.Username,
+			"avatar_url":   player.AvatarURL,
+			"is_ready":     player.IsReady,
+			"is_connected": player.IsConnected,
 		}
 
-		// Роль показываем только после окончания игры
-		if r.state.Status == StatusFinished {
+		// Роль показываем только после окончания игры (выбывший шпион раскрыт сразу)
+		if r.state.Status == StatusFinished || player.Eliminated {
 			p["role"] = player.Role
 		}
 
+		if player.Eliminated {
+			p["eliminated"] = true
+		}
+
+		// Оставшиеся обвинения в раунде
+		if r.state.Status != StatusWaiting && r.state.AccusationsPerPlayer != AccusationsUnlimited {
+			p["accusations_left"] = max(r.state.AccusationsPerPlayer-r.state.Accusations[player.UserID], 0)
+		}
+
 		players = append(players, p)
 	}
 
 	state := map[string]interface{}{
-		"room_id":     r.state.RoomID,
-		"status":      r.state.Status,
-		"players":     players,
-		"max_players": r.state.MaxPlayers,
-		"deck_name":   r.state.DeckName,
-		"created_by":  r.state.CreatedBy, // ID создателя комнаты
+		"room_id":                r.state.RoomID,
+		"status":                 r.state.Status,
+		"players":                players,
+		"max_players":            r.state.MaxPlayers,
+		"spy_count":              r.state.SpyCount, // 0 - "Авто"
+		"duration":               r.state.Duration,
+		"timer_mode":             r.state.TimerMode,
+		"per_player_seconds":     r.state.PerPlayerSeconds,
+		"vote_timeout":           r.state.VoteTimeout,
+		"missing_vote_policy":    r.state.MissingVotePolicy,
+		"accusations_per_player": r.state.AccusationsPerPlayer,
+		"vote_cooldown":          r.state.VoteCooldown,
+		"reveal_spy_partners":    r.state.RevealSpyPartners,
+		"eliminate_caught_spies": r.state.EliminateCaughtSpies,
+		"spy_last_chance":        r.state.SpyLastChance,
+		"last_chance_seconds":    r.state.LastChanceSeconds,
+		"timeout_rule":           r.state.TimeoutRule,
+		"final_turn_seconds":     r.state.FinalTurnSeconds,
+		"rounds":                 r.state.Rounds,
+		"round":                  r.state.Round,
+		"auto_start":             r.state.AutoStart,
+		"allow_duplicate_roles":  r.state.AllowDuplicateRoles,
+		"deck_name":              r.state.DeckName,
+		"created_by":             r.state.CreatedBy, // ID создателя комнаты
+		"host_id":                r.state.HostID,    // ID текущего админа комнаты
 	}
 
 	if r.state.TimerEnd != nil {
 		state["timer_end"] = r.state.TimerEnd.Unix()
 	}
 
+	// Таймер остановлен (пауза или голосование)
+	state["paused"] = r.state.Paused
+	state["freeze_on_vote"] = r.state.FreezeOnVote
+	if r.state.RemainingTime > 0 {
+		state["remaining_seconds"] = int(r.state.RemainingTime.Seconds())
+	}
+
+	if r.state.LastVoteEndedAt != nil && r.state.Status == StatusPlaying {
+		state["next_vote_at"] = r.state.LastVoteEndedAt.Add(time.Duration(r.state.VoteCooldown) * time.Second).Unix()
+	}
+
 	if r.state.Voting != nil {
-		state["voting"] = map[string]interface{}{
+		voting := map[string]interface{}{
 			"target_user_id": r.state.Voting.TargetUserID,
 			"votes":          r.state.Voting.Votes,
+			"deadline":       r.state.Voting.Deadline.Unix(),
+		}
+		// На паузе дедлайн стоит: клиент показывает остаток
+		if r.state.Voting.Remaining > 0 {
+			voting["remaining_seconds"] = int(r.state.Voting.Remaining.Seconds())
+		}
+		state["voting"] = voting
+	}
+
+	if fa := r.state.FinalAccusation; fa != nil && (r.state.Status == StatusFinalAccusation || r.state.Status == StatusVoting) {
+		state["final_accusation"] = map[string]interface{}{
+			"order":           fa.Order,
+			"current_user_id": r.currentFinalTurn(),
+			"turn_deadline":   fa.TurnDeadline.Unix(),
+		}
+	}
+
+	// Счет матча
+	if len(r.state.Scores) > 0 {
+		state["scores"] = r.state.Scores
+	}
+
+	// Очередь вопросов, чтобы интерфейс подсвечивал, кто сейчас спрашивает и кому отвечать
+	if r.state.Status == StatusPlaying || r.state.Status == StatusVoting {
+		state["current_asker_id"] = r.state.CurrentAskerID
+		state["asked_by_id"] = r.state.AskedByID
+		state["question_history"] = r.state.QuestionHistory
+	}
+
+	if r.state.Status == StatusLastChance {
+		state["last_chance"] = map[string]interface{}{
+			"spy_id":   r.state.LastChance.SpyID,
+			"deadline": r.state.LastChance.Deadline.Unix(),
 		}
 	}
 
@@ -681,6 +2124,68 @@ func (r *Room) getPublicState() map[string]interface{} {
 	return state
 }
 
+// Summary возвращает краткую информацию о комнате для лобби
+func (r *Room) Summary() RoomSummary {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	summary := RoomSummary{
+		ID:           r.state.RoomID,
+		DeckID:       r.state.DeckID,
+		DeckName:     r.state.DeckName,
+		PlayersCount: len(r.state.Players),
+		MaxPlayers:   r.state.MaxPlayers,
+		Status:       r.state.Status,
+		InMatch:      r.midMatch(),
+		HostID:       r.state.HostID,
+		CreatedAt:    r.state.CreatedAt,
+	}
+
+	if host, exists := r.state.Players[r.state.HostID]; exists {
+		summary.HostName = host.Username
+	}
+
+	return summary
+}
+
+// Close останавливает таймеры комнаты
+func (r *Room) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.stopTimers()
+}
+
+// closeIfEmpty закрывает комнату без игроков и удаляет ее состояние из Redis.
+// Возвращает false, если в комнату успели войти
+func (r *Room) closeIfEmpty() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.state.Players) > 0 {
+		return false
+	}
+
+	r.closed = true
+	r.stopTimers()
+	r.redis.Del(context.Background(), fmt.Sprintf("room:%s", r.state.RoomID))
+
+	return true
+}
+
+// stopTimers останавливает все таймеры комнаты
+func (r *Room) stopTimers() {
+	if r.timer != nil {
+		r.timer.Stop()
+	}
+	r.stopPhaseTimers()
+
+	for userID, timer := range r.graceTimers {
+		timer.Stop()
+		delete(r.graceTimers, userID)
+	}
+}
+
 // saveToRedis сохраняет состояние в Redis
 func (r *Room) saveToRedis() {
 	ctx := context.Background()
@@ -0,0 +1,82 @@
+package game
+
+import "testing"
+
+func TestTallyVotes(t *testing.T) {
+	const target = 5
+
+	tests := []struct {
+		name         string
+		policy       MissingVotePolicy
+		votes        map[uint]VoteChoice
+		disconnected uint // Не проголосовавший отключенный игрок
+		wantPassed   bool
+		wantMissing  int
+	}{
+		{"against: unanimous", MissingVoteAgainst,
+			map[uint]VoteChoice{1: VoteFor, 2: VoteFor, 3: VoteFor, 4: VoteFor}, 0, true, 0},
+		{"against: one against", MissingVoteAgainst,
+			map[uint]VoteChoice{1: VoteFor, 2: VoteFor, 3: VoteFor, 4: VoteAgainst}, 0, false, 0},
+		{"against: abstain counts against", MissingVoteAgainst,
+			map[uint]VoteChoice{1: VoteFor, 2: VoteFor, 3: VoteFor, 4: VoteAbstain}, 0, false, 0},
+		{"against: missing counts against", MissingVoteAgainst,
+			map[uint]VoteChoice{1: VoteFor, 2: VoteFor, 3: VoteFor}, 0, false, 1},
+		{"against: disconnected non-voter ignored", MissingVoteAgainst,
+			map[uint]VoteChoice{1: VoteFor, 2: VoteFor, 3: VoteFor}, 4, true, 0},
+		{"quorum: majority for, one missing", MissingVoteQuorum,
+			map[uint]VoteChoice{1: VoteFor, 2: VoteFor, 3: VoteFor}, 0, true, 1},
+		{"quorum: abstain joins quorum", MissingVoteQuorum,
+			map[uint]VoteChoice{1: VoteFor, 2: VoteFor, 3: VoteAbstain}, 0, true, 1},
+		{"quorum: no quorum", MissingVoteQuorum,
+			map[uint]VoteChoice{1: VoteFor, 2: VoteFor}, 0, false, 2},
+		{"quorum: one against blocks", MissingVoteQuorum,
+			map[uint]VoteChoice{1: VoteFor, 2: VoteFor, 3: VoteFor, 4: VoteAgainst}, 0, false, 0},
+		{"quorum: all abstain", MissingVoteQuorum,
+			map[uint]VoteChoice{1: VoteAbstain, 2: VoteAbstain, 3: VoteAbstain, 4: VoteAbstain}, 0, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			players := make(map[uint]*Player)
+			for id := uint(1); id <= target; id++ {
+				players[id] = &Player{UserID: id, IsConnected: id != tt.disconnected}
+			}
+
+			r := &Room{state: &RoomState{
+				Players:           players,
+				MissingVotePolicy: tt.policy,
+				Voting:            &VotingState{TargetUserID: target, Votes: tt.votes},
+			}}
+
+			tally := r.tallyVotes(true)
+			if tally.Passed != tt.wantPassed || tally.Missing != tt.wantMissing {
+				t.Errorf("tallyVotes() = %+v, want passed=%v missing=%d", tally, tt.wantPassed, tt.wantMissing)
+			}
+		})
+	}
+}
+
+func TestPickRole(t *testing.T) {
+	roles := []string{"Врач", "Медсестра"}
+
+	tests := []struct {
+		name            string
+		roles           []string
+		index           int
+		allowDuplicates bool
+		want            string
+	}{
+		{"unique role", roles, 1, false, "Медсестра"},
+		{"out of roles, fallback", roles, 2, false, "Гость"},
+		{"out of roles, duplicate", roles, 3, true, "Медсестра"},
+		{"no roles at all", nil, 0, true, "Гость"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pickRole(tt.roles, tt.index, tt.allowDuplicates, "Гость"); got != tt.want {
+				t.Errorf("pickRole(%d) = %q, want %q", tt.index, got, tt.want)
+			}
+		})
+	}
+}
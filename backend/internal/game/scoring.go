@@ -0,0 +1,54 @@
+package game
+
+// Очки за раунд по правилам Spyfall
+const (
+	SpyTimeoutPoints = 2 // Шпиона не раскрыли до конца времени
+	SpyGuessPoints   = 4 // Шпион остановил игру и угадал локацию
+	SpyFramedPoints  = 4 // Местные осудили невиновного
+	LocalWinPoints   = 1 // Каждому местному за поимку шпиона
+	AccuserPoints    = 2 // Местному, выдвинувшему верное обвинение (вместо LocalWinPoints)
+)
+
+// roundPoints возвращает очки победителей завершенного раунда (user_id -> очки)
+func (r *Room) roundPoints() map[uint]int {
+	points := make(map[uint]int)
+	for id, player := range r.state.Players {
+		if !r.isWinner(player) {
+			continue
+		}
+
+		switch {
+		case player.Role == RoleSpy && r.state.WinReason == WinSpyGuessed:
+			points[id] = SpyGuessPoints
+		case player.Role == RoleSpy && r.state.WinReason == WinWrongAccusation:
+			points[id] = SpyFramedPoints
+		case player.Role == RoleSpy:
+			points[id] = SpyTimeoutPoints
+		case id == r.state.AccuserID:
+			points[id] = AccuserPoints
+		default:
+			points[id] = LocalWinPoints
+		}
+	}
+	return points
+}
+
+// addScores прибавляет очки раунда к счету матча
+func (r *Room) addScores(points map[uint]int) {
+	if r.state.Scores == nil {
+		r.state.Scores = make(map[uint]int)
+	}
+	for id, p := range points {
+		r.state.Scores[id] += p
+	}
+}
+
+// matchOver проверяет, сыграны ли все раунды матча
+func (r *Room) matchOver() bool {
+	return r.state.Round >= r.state.Rounds
+}
+
+// midMatch проверяет, что комната ждет следующего раунда начатого матча
+func (r *Room) midMatch() bool {
+	return r.state.Round > 0 && !r.matchOver()
+}
@@ -0,0 +1,48 @@
+package game
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestRoundPoints(t *testing.T) {
+	const spy, accuser = 1, 2
+
+	tests := []struct {
+		name       string
+		winner     string
+		reason     WinReason
+		accuserID  uint
+		eliminated bool
+		want       map[uint]int
+	}{
+		{"spy survives timeout", "spy", WinTimeout, 0, false, map[uint]int{spy: SpyTimeoutPoints}},
+		{"spy guessed location", "spy", WinSpyGuessed, 0, false, map[uint]int{spy: SpyGuessPoints}},
+		{"locals framed innocent", "spy", WinWrongAccusation, 0, false, map[uint]int{spy: SpyFramedPoints}},
+		{"eliminated spy scores nothing", "spy", WinTimeout, 0, true, map[uint]int{}},
+		{"spy caught, accuser bonus", "locals", WinSpyCaught, accuser, false,
+			map[uint]int{accuser: AccuserPoints, 3: LocalWinPoints, 4: LocalWinPoints}},
+		{"spy guessed wrong", "locals", WinWrongGuess, 0, false,
+			map[uint]int{accuser: LocalWinPoints, 3: LocalWinPoints, 4: LocalWinPoints}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Room{state: &RoomState{
+				Players: map[uint]*Player{
+					spy: {UserID: spy, Role: RoleSpy, Eliminated: tt.eliminated},
+					2:   {UserID: 2, Role: RoleLocal},
+					3:   {UserID: 3, Role: RoleLocal},
+					4:   {UserID: 4, Role: RoleLocal},
+				},
+				Winner:    tt.winner,
+				WinReason: tt.reason,
+				AccuserID: tt.accuserID,
+			}}
+
+			if got := r.roundPoints(); !maps.Equal(got, tt.want) {
+				t.Errorf("roundPoints() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Chelaran/mayoku/internal/models"
+)
+
+// ReconnectGracePeriod время, в течение которого отключившийся игрок сохраняет место в комнате
+const ReconnectGracePeriod = 90 * time.Second
+
+// EmptyRoomTTL сколько созданная комната ждет первого игрока, прежде чем удалиться
+const EmptyRoomTTL = 2 * time.Minute
+
+// MaxQuestionHistory сколько последних ходов хранится в истории вопросов
+const MaxQuestionHistory = 50
+
+// SpyCountAuto количество шпионов определяется числом игроков при старте
+const SpyCountAuto = 0
+
+// Ограничения таймера раунда
+const (
+	MinDuration = 3  // В минутах
+	MaxDuration = 15 // В минутах
+
+	MinPerPlayerSeconds = 30
+	MaxPerPlayerSeconds = 180
+
+	DefaultMaxPlayers       = 8
+	DefaultSpyCount         = 1
+	DefaultDuration         = 8
+	DefaultPerPlayerSeconds = 60 // "Обычно 1 мин на игрока"
+)
+
+// Количество раундов в матче
+const (
+	MinRounds     = 1
+	MaxRounds     = 10
+	DefaultRounds = 1 // Одиночная игра
+)
+
+// Ограничения голосования
+const (
+	MinVoteTimeout = 15  // В секундах
+	MaxVoteTimeout = 180 // В секундах
+
+	DefaultVoteTimeout       = 60
+	DefaultMissingVotePolicy = MissingVoteAgainst
+
+	MinLastChanceSeconds     = 10
+	MaxLastChanceSeconds     = 120
+	DefaultLastChanceSeconds = 30
+
+	MinFinalTurnSeconds     = 10
+	MaxFinalTurnSeconds     = 120
+	DefaultFinalTurnSeconds = 30
+	DefaultTimeoutRule      = TimeoutSpyWins
+)
+
+// Ограничения обвинений
+const (
+	AccusationsUnlimited    = 0
+	MaxAccusationsPerPlayer = 5
+	MaxVoteCooldown         = 300 // В секундах
+
+	DefaultAccusationsPerPlayer = 1 // Классический Spyfall: одно обвинение на игрока
+	DefaultVoteCooldown         = 30
+)
+
+// Limits серверные ограничения настроек комнаты
+type Limits struct {
+	MinPlayers  int
+	MaxPlayers  int
+	MaxSpyCount int
+	AutoSpies   map[int]int // Минимальное число игроков -> количество шпионов в режиме "Авто"
+}
+
+// Validate проверяет сами ограничения (из конфигурации)
+func (l Limits) Validate() error {
+	if l.MinPlayers < 3 {
+		return fmt.Errorf("min players must be at least 3")
+	}
+
+	if l.MaxPlayers < l.MinPlayers {
+		return fmt.Errorf("max players (%d) must not be less than min players (%d)", l.MaxPlayers, l.MinPlayers)
+	}
+
+	// Каждому местному нужна своя роль, а в локации их не больше MaxLocationRoles
+	if l.MaxPlayers-1 > models.MaxLocationRoles {
+		return fmt.Errorf("max players (%d) exceeds what a location can serve (%d roles)", l.MaxPlayers, models.MaxLocationRoles)
+	}
+
+	if l.MaxSpyCount < 1 {
+		return fmt.Errorf("max spy count must be at least 1")
+	}
+
+	for players, spies := range l.AutoSpies {
+		if players < 1 || spies < 1 || spies > l.MaxSpyCount {
+			return fmt.Errorf("invalid auto spy rule %d:%d", players, spies)
+		}
+	}
+
+	return nil
+}
+
+// DefaultMaxPlayers возвращает лимит игроков для новой комнаты
+func (l Limits) DefaultMaxPlayers() int {
+	return min(max(DefaultMaxPlayers, l.MinPlayers), l.MaxPlayers)
+}
+
+// ValidateSettings проверяет изменение настроек комнаты
+func (l Limits) ValidateSettings(update SettingsUpdate) error {
+	if update.MaxPlayers != nil && (*update.MaxPlayers < l.MinPlayers || *update.MaxPlayers > l.MaxPlayers) {
+		return fmt.Errorf("max_players must be between %d and %d", l.MinPlayers, l.MaxPlayers)
+	}
+
+	if update.SpyCount != nil && *update.SpyCount != SpyCountAuto && (*update.SpyCount < 1 || *update.SpyCount > l.MaxSpyCount) {
+		return fmt.Errorf("spy_count must be between 1 and %d, or %d for auto", l.MaxSpyCount, SpyCountAuto)
+	}
+
+	if update.Duration != nil && (*update.Duration < MinDuration || *update.Duration > MaxDuration) {
+		return fmt.Errorf("duration must be between %d and %d minutes", MinDuration, MaxDuration)
+	}
+
+	if update.TimerMode != nil {
+		switch *update.TimerMode {
+		case TimerFixed, TimerPerPlayer, TimerUntimed:
+		default:
+			return fmt.Errorf("timer_mode must be %q, %q or %q", TimerFixed, TimerPerPlayer, TimerUntimed)
+		}
+	}
+
+	if update.PerPlayerSeconds != nil && (*update.PerPlayerSeconds < MinPerPlayerSeconds || *update.PerPlayerSeconds > MaxPerPlayerSeconds) {
+		return fmt.Errorf("per_player_seconds must be between %d and %d", MinPerPlayerSeconds, MaxPerPlayerSeconds)
+	}
+
+	if update.VoteTimeout != nil && (*update.VoteTimeout < MinVoteTimeout || *update.VoteTimeout > MaxVoteTimeout) {
+		return fmt.Errorf("vote_timeout must be between %d and %d seconds", MinVoteTimeout, MaxVoteTimeout)
+	}
+
+	if update.AccusationsPerPlayer != nil && (*update.AccusationsPerPlayer < AccusationsUnlimited || *update.AccusationsPerPlayer > MaxAccusationsPerPlayer) {
+		return fmt.Errorf("accusations_per_player must be between 1 and %d, or %d for unlimited", MaxAccusationsPerPlayer, AccusationsUnlimited)
+	}
+
+	if update.VoteCooldown != nil && (*update.VoteCooldown < 0 || *update.VoteCooldown > MaxVoteCooldown) {
+		return fmt.Errorf("vote_cooldown must be between 0 and %d seconds", MaxVoteCooldown)
+	}
+
+	if update.LastChanceSeconds != nil && (*update.LastChanceSeconds < MinLastChanceSeconds || *update.LastChanceSeconds > MaxLastChanceSeconds) {
+		return fmt.Errorf("last_chance_seconds must be between %d and %d", MinLastChanceSeconds, MaxLastChanceSeconds)
+	}
+
+	if update.Rounds != nil && (*update.Rounds < MinRounds || *update.Rounds > MaxRounds) {
+		return fmt.Errorf("rounds must be between %d and %d", MinRounds, MaxRounds)
+	}
+
+	if update.TimeoutRule != nil {
+		switch *update.TimeoutRule {
+		case TimeoutSpyWins, TimeoutFinalAccusation:
+		default:
+			return fmt.Errorf("timeout_rule must be %q or %q", TimeoutSpyWins, TimeoutFinalAccusation)
+		}
+	}
+
+	if update.FinalTurnSeconds != nil && (*update.FinalTurnSeconds < MinFinalTurnSeconds || *update.FinalTurnSeconds > MaxFinalTurnSeconds) {
+		return fmt.Errorf("final_turn_seconds must be between %d and %d", MinFinalTurnSeconds, MaxFinalTurnSeconds)
+	}
+
+	if update.MissingVotePolicy != nil {
+		switch *update.MissingVotePolicy {
+		case MissingVoteAgainst, MissingVoteQuorum:
+		default:
+			return fmt.Errorf("missing_vote_policy must be %q or %q", MissingVoteAgainst, MissingVoteQuorum)
+		}
+	}
+
+	return nil
+}
+
+// ResolveSpyCount возвращает фактическое количество шпионов для players игроков.
+// В режиме "Авто" берется правило с наибольшим порогом, не превышающим players.
+// Местных всегда остается больше, чем шпионов.
+func (l Limits) ResolveSpyCount(spyCount, players int) int {
+	if spyCount == SpyCountAuto {
+		spyCount = 1
+
+		thresholds := make([]int, 0, len(l.AutoSpies))
+		for threshold := range l.AutoSpies {
+			thresholds = append(thresholds, threshold)
+		}
+		sort.Ints(thresholds)
+
+		for _, threshold := range thresholds {
+			if players >= threshold {
+				spyCount = l.AutoSpies[threshold]
+			}
+		}
+	}
+
+	if maxSpies := (players - 1) / 2; spyCount > maxSpies {
+		spyCount = maxSpies
+	}
+
+	return max(spyCount, 1)
+}
@@ -0,0 +1,31 @@
+package game
+
+import "testing"
+
+func TestResolveSpyCount(t *testing.T) {
+	limits := Limits{MinPlayers: 3, MaxPlayers: 10, MaxSpyCount: 2, AutoSpies: map[int]int{3: 1, 7: 2}}
+
+	tests := []struct {
+		name     string
+		spyCount int
+		players  int
+		want     int
+	}{
+		{"auto below first threshold", SpyCountAuto, 2, 1},
+		{"auto first threshold", SpyCountAuto, 3, 1},
+		{"auto just below second threshold", SpyCountAuto, 6, 1},
+		{"auto second threshold", SpyCountAuto, 7, 2},
+		{"auto above last threshold", SpyCountAuto, 10, 2},
+		{"fixed within cap", 2, 5, 2},
+		{"fixed capped by (players-1)/2", 2, 4, 1},
+		{"fixed capped, never below one", 2, 2, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := limits.ResolveSpyCount(tt.spyCount, tt.players); got != tt.want {
+				t.Errorf("ResolveSpyCount(%d, %d) = %d, want %d", tt.spyCount, tt.players, got, tt.want)
+			}
+		})
+	}
+}
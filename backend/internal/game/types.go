@@ -17,10 +17,57 @@ const (
 type GameStatus string
 
 const (
-	StatusWaiting  GameStatus = "waiting"  // Ожидание игроков
-	StatusPlaying  GameStatus = "playing"  // Игра идет
-	StatusVoting   GameStatus = "voting"   // Идет голосование
-	StatusFinished GameStatus = "finished" // Игра завершена
+	StatusWaiting         GameStatus = "waiting"          // Ожидание игроков
+	StatusPlaying         GameStatus = "playing"          // Игра идет
+	StatusVoting          GameStatus = "voting"           // Идет голосование
+	StatusLastChance      GameStatus = "last_chance"      // Пойманный шпион называет локацию, чтобы забрать победу
+	StatusFinalAccusation GameStatus = "final_accusation" // Время вышло: игроки по очереди выдвигают обвинение
+	StatusFinished        GameStatus = "finished"         // Игра завершена
+)
+
+// TimerMode режим таймера раунда
+type TimerMode string
+
+const (
+	TimerFixed     TimerMode = "fixed"      // Фиксированная длительность (Duration минут)
+	TimerPerPlayer TimerMode = "per_player" // PerPlayerSeconds на каждого игрока
+	TimerUntimed   TimerMode = "untimed"    // Без таймера: игра заканчивается голосованием или догадкой шпиона
+)
+
+// VoteChoice голос игрока
+type VoteChoice string
+
+const (
+	VoteFor     VoteChoice = "for"
+	VoteAgainst VoteChoice = "against"
+	VoteAbstain VoteChoice = "abstain"
+)
+
+// WinReason как закончился раунд (влияет на начисление очков)
+type WinReason string
+
+const (
+	WinTimeout         WinReason = "timeout"          // Время вышло, шпиона не нашли
+	WinSpyGuessed      WinReason = "spy_guessed"      // Шпион угадал локацию
+	WinWrongAccusation WinReason = "wrong_accusation" // Осудили невиновного
+	WinSpyCaught       WinReason = "spy_caught"       // Шпиона поймали голосованием
+	WinWrongGuess      WinReason = "wrong_guess"      // Шпион ошибся с локацией
+)
+
+// TimeoutRule что происходит, когда время раунда вышло
+type TimeoutRule string
+
+const (
+	TimeoutSpyWins         TimeoutRule = "spy_wins"         // Победа шпиона
+	TimeoutFinalAccusation TimeoutRule = "final_accusation" // Финальный раунд обвинений по очереди
+)
+
+// MissingVotePolicy как учитываются не проголосовавшие к дедлайну игроки
+type MissingVotePolicy string
+
+const (
+	MissingVoteAgainst MissingVotePolicy = "against" // Воздержавшиеся и не проголосовавшие считаются голосами "против"
+	MissingVoteQuorum  MissingVotePolicy = "quorum"  // Решают проголосовавшие, если их больше половины
 )
 
 // Player представляет игрока в комнате
@@ -33,41 +80,184 @@ type Player struct {
 	Location     string     `json:"location,omitempty"`      // Только для Local
 	LocationRole string     `json:"location_role,omitempty"` // Роль в локации (только для Local)
 	IsReady      bool       `json:"is_ready"`
-	IsVoted      bool       `json:"is_voted,omitempty"` // Для голосования
-	Vote         bool       `json:"vote,omitempty"`     // true = за, false = против
+	JoinedAt     time.Time  `json:"joined_at"`            // Для передачи прав хоста
+	Eliminated   bool       `json:"eliminated,omitempty"` // Пойманный шпион, выбывший из игры
+	IsVoted      bool       `json:"is_voted,omitempty"`   // Для голосования
+	Vote         VoteChoice `json:"vote,omitempty"`
+
+	// Присутствие: отключенный игрок сохраняет место и роль в течение ReconnectGracePeriod
+	IsConnected    bool       `json:"is_connected"`
+	DisconnectedAt *time.Time `json:"disconnected_at,omitempty"`
 }
 
 // LocationInfo информация о локации
 type LocationInfo struct {
-	Name     string   `json:"name"`
-	ImageURL string   `json:"image_url"`
-	Roles    []string `json:"roles"`
+	ID           uint     `json:"id"`
+	Name         string   `json:"name"`
+	ImageURL     string   `json:"image_url"`
+	ThumbnailURL string   `json:"thumbnail_url,omitempty"`
+	Roles        []string `json:"roles"`
+}
+
+// LocationOption локация колоды в списке вариантов для догадки шпиона
+type LocationOption struct {
+	ID           uint   `json:"id"`
+	Name         string `json:"name"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
 }
 
 // VotingState состояние голосования
 type VotingState struct {
-	TargetUserID uint          `json:"target_user_id"`
-	Votes        map[uint]bool `json:"votes"` // user_id -> vote (true/false)
-	StartedAt    time.Time     `json:"started_at"`
+	InitiatorID  uint                `json:"initiator_id"`
+	TargetUserID uint                `json:"target_user_id"`
+	Votes        map[uint]VoteChoice `json:"votes"` // user_id -> vote
+	StartedAt    time.Time           `json:"started_at"`
+	Deadline     time.Time           `json:"deadline"`
+	// Остаток до дедлайна, пока игра на паузе
+	Remaining time.Duration `json:"remaining,omitempty"`
+}
+
+// LastChanceState состояние фазы "последний шанс"
+type LastChanceState struct {
+	SpyID    uint      `json:"spy_id"`
+	Deadline time.Time `json:"deadline"`
+}
+
+// FinalAccusationState состояние финального раунда обвинений
+type FinalAccusationState struct {
+	Order        []uint    `json:"order"` // Очередь игроков
+	Turn         int       `json:"turn"`  // Индекс текущего игрока в Order
+	TurnDeadline time.Time `json:"turn_deadline"`
+}
+
+// QuestionTurn вопрос в истории ходов: кто спросил и кого
+type QuestionTurn struct {
+	AskerID  uint      `json:"asker_id"`
+	TargetID uint      `json:"target_id"`
+	AskedAt  time.Time `json:"asked_at"`
+}
+
+// VoteTally итог голосования (событие vote_result)
+type VoteTally struct {
+	TargetUserID uint              `json:"target_user_id"`
+	For          int               `json:"for"`
+	Against      int               `json:"against"`
+	Abstained    int               `json:"abstained"`
+	Missing      int               `json:"missing"` // Подключенные, но не проголосовавшие
+	Policy       MissingVotePolicy `json:"policy"`
+	TimedOut     bool              `json:"timed_out"`
+	Passed       bool              `json:"passed"`
 }
 
 // RoomState состояние комнаты
 type RoomState struct {
-	RoomID     string           `json:"room_id"`
-	Status     GameStatus       `json:"status"`
-	Players    map[uint]*Player `json:"players"` // user_id -> Player
-	Location   *LocationInfo    `json:"location,omitempty"`
-	SpyIDs     []uint           `json:"spy_ids,omitempty"` // ID шпионов
-	TimerEnd   *time.Time       `json:"timer_end,omitempty"`
-	Voting     *VotingState     `json:"voting,omitempty"`
-	Winner     string           `json:"winner,omitempty"` // "spy" | "locals"
-	DeckID     uint             `json:"deck_id"`
-	DeckName   string           `json:"deck_name"`
-	MaxPlayers int              `json:"max_players"`
-	SpyCount   int              `json:"spy_count"` // Количество шпионов
-	Duration   int              `json:"duration"`  // В минутах
-	CreatedBy  uint             `json:"created_by"`
-	CreatedAt  time.Time        `json:"created_at"`
+	RoomID   string           `json:"room_id"`
+	Status   GameStatus       `json:"status"`
+	Players  map[uint]*Player `json:"players"` // user_id -> Player
+	Location *LocationInfo    `json:"location,omitempty"`
+	// Все локации колоды в перемешанном порядке (варианты для догадки шпиона)
+	DeckLocations   []LocationOption      `json:"deck_locations,omitempty"`
+	SpyIDs          []uint                `json:"spy_ids,omitempty"` // ID шпионов
+	TimerEnd        *time.Time            `json:"timer_end,omitempty"`
+	Voting          *VotingState          `json:"voting,omitempty"`
+	LastChance      *LastChanceState      `json:"last_chance,omitempty"`
+	FinalAccusation *FinalAccusationState `json:"final_accusation,omitempty"`
+	// Очередь вопросов: кто сейчас спрашивает, кто спросил его (ему нельзя задать вопрос в ответ) и история ходов
+	CurrentAskerID  uint           `json:"current_asker_id,omitempty"`
+	AskedByID       uint           `json:"asked_by_id,omitempty"`
+	QuestionHistory []QuestionTurn `json:"question_history,omitempty"`
+	Winner          string         `json:"winner,omitempty"` // "spy" | "locals"
+	WinReason       WinReason      `json:"win_reason,omitempty"`
+	AccuserID       uint           `json:"accuser_id,omitempty"` // Кто выдвинул верное обвинение (бонусные очки)
+	DeckID          uint           `json:"deck_id"`
+	DeckName        string         `json:"deck_name"`
+	MaxPlayers      int            `json:"max_players"`
+	SpyCount        int            `json:"spy_count"` // Количество шпионов (SpyCountAuto - по числу игроков)
+	Duration        int            `json:"duration"`  // В минутах (для TimerFixed)
+	TimerMode       TimerMode      `json:"timer_mode"`
+	// Секунд на игрока (для TimerPerPlayer)
+	PerPlayerSeconds int `json:"per_player_seconds"`
+	// Останавливать таймер на время голосования
+	FreezeOnVote bool `json:"freeze_on_vote"`
+	// Время на голосование в секундах и учет не проголосовавших
+	VoteTimeout       int               `json:"vote_timeout"`
+	MissingVotePolicy MissingVotePolicy `json:"missing_vote_policy"`
+	// Обвинений на игрока за раунд (AccusationsUnlimited - без ограничения) и пауза между голосованиями в секундах
+	AccusationsPerPlayer int          `json:"accusations_per_player"`
+	VoteCooldown         int          `json:"vote_cooldown"`
+	Accusations          map[uint]int `json:"accusations,omitempty"` // user_id -> обвинений в текущем раунде
+	LastVoteEndedAt      *time.Time   `json:"last_vote_ended_at,omitempty"`
+	// Правила для нескольких шпионов: шпионы знают друг друга; пойманный шпион выбывает,
+	// а игра продолжается, пока не пойманы все
+	RevealSpyPartners    bool `json:"reveal_spy_partners"`
+	EliminateCaughtSpies bool `json:"eliminate_caught_spies"`
+	// Пойманный шпион получает LastChanceSeconds на финальную догадку
+	SpyLastChance     bool `json:"spy_last_chance"`
+	LastChanceSeconds int  `json:"last_chance_seconds"`
+	// Что происходит по истечении таймера; FinalTurnSeconds - время на ход в финальном раунде
+	TimeoutRule      TimeoutRule `json:"timeout_rule"`
+	FinalTurnSeconds int         `json:"final_turn_seconds"`
+	// Пауза (ставит хост). Пока таймер остановлен, TimerEnd = nil, а остаток хранится в RemainingTime
+	Paused        bool          `json:"paused"`
+	RemainingTime time.Duration `json:"remaining_time,omitempty"`
+	// Матч из Rounds раундов: номер текущего раунда, счет и уже сыгранные локации
+	Rounds          int          `json:"rounds"`
+	Round           int          `json:"round"`
+	Scores          map[uint]int `json:"scores,omitempty"` // user_id -> очки за матч
+	UsedLocationIDs []uint       `json:"used_location_ids,omitempty"`
+	// Игра стартует сама, когда все готовы; иначе ее запускает хост (start_game)
+	AutoStart bool `json:"auto_start"`
+	// Местным, которым не хватило уникальных ролей, роли выдаются повторно вместо запасной
+	AllowDuplicateRoles bool      `json:"allow_duplicate_roles"`
+	CreatedBy           uint      `json:"created_by"`
+	HostID              uint      `json:"host_id"` // Текущий админ комнаты (меняется, если хост ушел)
+	CreatedAt           time.Time `json:"created_at"`
+	StartedAt           time.Time `json:"started_at"` // Начало текущей игры
+}
+
+// SettingsUpdate изменение настроек комнаты (nil - значение не меняется)
+type SettingsUpdate struct {
+	MaxPlayers       *int       `json:"max_players,omitempty"`
+	SpyCount         *int       `json:"spy_count,omitempty"`
+	Duration         *int       `json:"duration,omitempty"`
+	DeckID           *uint      `json:"deck_id,omitempty"`
+	TimerMode        *TimerMode `json:"timer_mode,omitempty"`
+	PerPlayerSeconds *int       `json:"per_player_seconds,omitempty"`
+	FreezeOnVote     *bool      `json:"freeze_on_vote,omitempty"`
+
+	VoteTimeout       *int               `json:"vote_timeout,omitempty"`
+	MissingVotePolicy *MissingVotePolicy `json:"missing_vote_policy,omitempty"`
+
+	AccusationsPerPlayer *int `json:"accusations_per_player,omitempty"`
+	VoteCooldown         *int `json:"vote_cooldown,omitempty"`
+
+	RevealSpyPartners    *bool `json:"reveal_spy_partners,omitempty"`
+	EliminateCaughtSpies *bool `json:"eliminate_caught_spies,omitempty"`
+
+	SpyLastChance     *bool `json:"spy_last_chance,omitempty"`
+	LastChanceSeconds *int  `json:"last_chance_seconds,omitempty"`
+
+	TimeoutRule      *TimeoutRule `json:"timeout_rule,omitempty"`
+	FinalTurnSeconds *int         `json:"final_turn_seconds,omitempty"`
+
+	Rounds    *int  `json:"rounds,omitempty"`
+	AutoStart *bool `json:"auto_start,omitempty"`
+
+	AllowDuplicateRoles *bool `json:"allow_duplicate_roles,omitempty"`
+}
+
+// RoomSummary краткая информация о комнате для списка в лобби
+type RoomSummary struct {
+	ID           string     `json:"id"`
+	DeckID       uint       `json:"deck_id"`
+	DeckName     string     `json:"deck_name"`
+	PlayersCount int        `json:"players_count"`
+	MaxPlayers   int        `json:"max_players"`
+	Status       GameStatus `json:"status"`
+	InMatch      bool       `json:"in_match"` // Ждет следующего раунда матча, новых игроков не принимает
+	HostID       uint       `json:"host_id"`
+	HostName     string     `json:"host_name,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
 }
 
 // WSMessage сообщение WebSocket
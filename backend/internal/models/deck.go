@@ -14,15 +14,24 @@ const (
 	DeckStatusRejected DeckStatus = "rejected" // Отклонено
 )
 
+// MinDeckLocations минимальное количество локаций для отправки на модерацию
+const MinDeckLocations = 1
+
 // Deck представляет набор локаций (колоду)
 type Deck struct {
-	ID        uint       `gorm:"primaryKey" json:"id"`
-	AuthorID  uint       `gorm:"not null;index" json:"author_id"`
-	Name      string     `gorm:"not null" json:"name"`
-	IsPublic  bool       `gorm:"default:false" json:"is_public"`
-	Status    DeckStatus `gorm:"type:varchar(20);default:'draft'" json:"status"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	AuthorID     uint       `gorm:"not null;index" json:"author_id"`
+	Name         string     `gorm:"not null" json:"name"`
+	IsPublic     bool       `gorm:"default:false" json:"is_public"`
+	Status       DeckStatus `gorm:"type:varchar(20);default:'draft'" json:"status"`
+	FallbackRole string     `json:"fallback_role,omitempty"` // Запасная роль для локаций без своей
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+
+	// Модерация
+	RejectionReason string     `json:"rejection_reason,omitempty"` // Причина отклонения (видна автору)
+	ReviewedByID    *uint      `gorm:"index" json:"reviewed_by_id,omitempty"`
+	ReviewedAt      *time.Time `json:"reviewed_at,omitempty"`
 
 	// Связи
 	Author    User       `gorm:"foreignKey:AuthorID" json:"author,omitempty"`
@@ -33,3 +42,13 @@ type Deck struct {
 func (Deck) TableName() string {
 	return "decks"
 }
+
+// IsAvailableTo проверяет, может ли пользователь играть колодой: одобренной или своей
+func (d *Deck) IsAvailableTo(userID uint) bool {
+	return d.Status == DeckStatusApproved || d.AuthorID == userID
+}
+
+// IsEditable проверяет, может ли автор редактировать колоду
+func (d *Deck) IsEditable() bool {
+	return d.Status == DeckStatusDraft || d.Status == DeckStatusRejected
+}
@@ -7,10 +7,11 @@ import (
 // GameHistory представляет историю завершенной игры
 type GameHistory struct {
 	ID        uint      `gorm:"primaryKey" json:"id"`
-	RoomUUID  string    `gorm:"index;not null" json:"room_uuid"` // UUID комнаты для связи с логами
-	DeckName  string    `gorm:"not null" json:"deck_name"`       // Название колоды
-	Winner    string    `gorm:"not null" json:"winner"`          // "Spy" | "Locals"
-	Duration  int       `gorm:"not null" json:"duration"`        // Длительность в секундах
+	RoomUUID  string    `gorm:"index;not null" json:"room_uuid"`    // UUID комнаты для связи с логами
+	DeckName  string    `gorm:"not null" json:"deck_name"`          // Название колоды
+	Winner    string    `gorm:"not null" json:"winner"`             // "Spy" | "Locals"
+	Duration  int       `gorm:"not null" json:"duration"`           // Длительность в секундах
+	TimerMode string    `gorm:"type:varchar(20)" json:"timer_mode"` // "fixed" | "per_player" | "untimed"
 	CreatedAt time.Time `json:"created_at"`
 
 	// Связи
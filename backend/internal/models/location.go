@@ -4,9 +4,20 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 )
 
+// Ограничения на количество ролей в локации
+const (
+	MinLocationRoles = 6
+	MaxLocationRoles = 20
+)
+
+// DefaultFallbackRole роль местного, если ролей локации не хватило, а запасная роль не задана
+const DefaultFallbackRole = "Посетитель"
+
 // StringArray представляет массив строк для JSON сериализации в GORM
 type StringArray []string
 
@@ -35,13 +46,15 @@ func (a *StringArray) Scan(value interface{}) error {
 
 // Location представляет локацию в игре
 type Location struct {
-	ID        uint        `gorm:"primaryKey" json:"id"`
-	DeckID    uint        `gorm:"not null;index" json:"deck_id"`
-	Name      string      `gorm:"not null" json:"name"`
-	ImageURL  string      `json:"image_url"`               // Ссылка на MinIO
-	Roles     StringArray `gorm:"type:jsonb" json:"roles"` // ["Доктор", "Медсестра", ...]
-	CreatedAt time.Time   `json:"created_at"`
-	UpdatedAt time.Time   `json:"updated_at"`
+	ID           uint        `gorm:"primaryKey" json:"id"`
+	DeckID       uint        `gorm:"not null;index" json:"deck_id"`
+	Name         string      `gorm:"not null" json:"name"`
+	ImageURL     string      `json:"image_url"`               // Ссылка на MinIO (размер карточки)
+	ThumbnailURL string      `json:"thumbnail_url,omitempty"` // Миниатюра для списков
+	Roles        StringArray `gorm:"type:jsonb" json:"roles"` // ["Доктор", "Медсестра", ...]
+	FallbackRole string      `json:"fallback_role,omitempty"` // Роль для местных, которым не хватило ролей
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
 
 	// Связи
 	Deck Deck `gorm:"foreignKey:DeckID" json:"deck,omitempty"`
@@ -51,3 +64,43 @@ type Location struct {
 func (Location) TableName() string {
 	return "locations"
 }
+
+// Normalize обрезает пробелы в названии и ролях, удаляя пустые и повторяющиеся роли
+func (l *Location) Normalize() {
+	l.Name = strings.TrimSpace(l.Name)
+	l.ImageURL = strings.TrimSpace(l.ImageURL)
+	l.ThumbnailURL = strings.TrimSpace(l.ThumbnailURL)
+	l.FallbackRole = strings.TrimSpace(l.FallbackRole)
+
+	roles := make(StringArray, 0, len(l.Roles))
+	seen := make(map[string]bool, len(l.Roles))
+	for _, role := range l.Roles {
+		if role = strings.TrimSpace(role); role != "" && !seen[role] {
+			seen[role] = true
+			roles = append(roles, role)
+		}
+	}
+	l.Roles = roles
+}
+
+// Validate проверяет название и количество ролей локации
+func (l *Location) Validate() error {
+	if l.Name == "" {
+		return errors.New("location name is required")
+	}
+
+	if len(l.Roles) < MinLocationRoles || len(l.Roles) > MaxLocationRoles {
+		return fmt.Errorf("location %q must have between %d and %d roles", l.Name, MinLocationRoles, MaxLocationRoles)
+	}
+
+	return nil
+}
+
+// ValidateForPlayers проверяет, что ролей хватит всем местным в комнате на maxPlayers игроков (минимум один шпион)
+func (l *Location) ValidateForPlayers(maxPlayers int) error {
+	if need := maxPlayers - 1; len(l.Roles) < need {
+		return fmt.Errorf("location %q must have at least %d roles for %d players", l.Name, need, maxPlayers)
+	}
+
+	return nil
+}
@@ -0,0 +1,213 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+
+	// Регистрация декодеров для image.Decode
+	_ "image/gif"
+	_ "image/png"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// Размеры и качество производных картинок локаций
+const (
+	CardMaxSize      = 1024 // Карточка в игре, px по большей стороне
+	ThumbnailMaxSize = 320  // Миниатюра для списков, px по большей стороне
+	cardQuality      = 85
+	thumbnailQuality = 80
+
+	// Защита от "декомпрессионных бомб"
+	maxSourcePixels = 40_000_000
+)
+
+// ProcessedImage картинка, приведенная к размеру карточки, и ее миниатюра (JPEG без EXIF)
+type ProcessedImage struct {
+	Card      []byte
+	Thumbnail []byte
+}
+
+// ProcessImage декодирует картинку, применяет EXIF ориентацию и перекодирует в JPEG.
+// Повторное кодирование отбрасывает все метаданные, включая EXIF.
+func ProcessImage(data []byte) (*ProcessedImage, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+	if cfg.Width*cfg.Height > maxSourcePixels {
+		return nil, errors.New("image dimensions are too large")
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	// Поворот после уменьшения: результат тот же, но обрабатывается меньше пикселей
+	orientation := jpegOrientation(data)
+
+	card, err := encodeJPEG(applyOrientation(resizeToFit(src, CardMaxSize), orientation), cardQuality)
+	if err != nil {
+		return nil, err
+	}
+
+	thumbnail, err := encodeJPEG(applyOrientation(resizeToFit(src, ThumbnailMaxSize), orientation), thumbnailQuality)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProcessedImage{
+		Card:      card,
+		Thumbnail: thumbnail,
+	}, nil
+}
+
+// resizeToFit уменьшает картинку до maxSize по большей стороне (без увеличения)
+// и заливает прозрачность белым, так как JPEG не поддерживает альфа-канал
+func resizeToFit(src image.Image, maxSize int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width > maxSize || height > maxSize {
+		if width >= height {
+			height = max(1, height*maxSize/width)
+			width = maxSize
+		} else {
+			width = max(1, width*maxSize/height)
+			height = maxSize
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+
+	return dst
+}
+
+// encodeJPEG кодирует картинку в JPEG
+func encodeJPEG(img image.Image, quality int) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// applyOrientation поворачивает/отражает картинку согласно EXIF Orientation (1-8)
+func applyOrientation(src image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return src
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	// Для 5-8 ширина и высота меняются местами
+	dstW, dstH := width, height
+	if orientation >= 5 {
+		dstW, dstH = height, width
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // Отражение по горизонтали
+				dx, dy = width-1-x, y
+			case 3: // Поворот на 180
+				dx, dy = width-1-x, height-1-y
+			case 4: // Отражение по вертикали
+				dx, dy = x, height-1-y
+			case 5: // Транспонирование
+				dx, dy = y, x
+			case 6: // Поворот на 90 по часовой
+				dx, dy = height-1-y, x
+			case 7: // Транспонирование с поворотом
+				dx, dy = height-1-y, width-1-x
+			case 8: // Поворот на 90 против часовой
+				dx, dy = y, width-1-x
+			}
+			dst.Set(dx, dy, src.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+
+	return dst
+}
+
+// jpegOrientation читает тег Orientation из EXIF (APP1) сегмента JPEG.
+// Возвращает 1 (без поворота), если тег не найден.
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return 1
+		}
+		marker := data[pos+1]
+		// Начало сжатых данных - дальше метаданных нет
+		if marker == 0xDA || marker == 0xD9 {
+			return 1
+		}
+
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+		if length < 2 || pos+2+length > len(data) {
+			return 1
+		}
+		segment := data[pos+4 : pos+2+length]
+
+		if marker == 0xE1 && len(segment) > 6 && string(segment[:6]) == "Exif\x00\x00" {
+			return exifOrientation(segment[6:])
+		}
+
+		pos += 2 + length
+	}
+
+	return 1
+}
+
+// exifOrientation ищет тег 0x0112 в IFD0 TIFF заголовка
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd+2 > len(tiff) {
+		return 1
+	}
+
+	entries := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < entries; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			return int(order.Uint16(tiff[entry+8:]))
+		}
+	}
+
+	return 1
+}
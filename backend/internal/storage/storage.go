@@ -0,0 +1,181 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/Chelaran/mayoku/internal/config"
+	"github.com/google/uuid"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// ErrTooLarge объект превышает допустимый размер
+var ErrTooLarge = errors.New("object is too large")
+
+// Storage хранит загруженные файлы в MinIO и строит на них публичные ссылки
+type Storage struct {
+	client        *minio.Client
+	presignClient *minio.Client // Подписывает URL для публичного адреса MinIO
+	bucket        string
+	publicURL     string
+}
+
+// New создает Storage поверх подключения к MinIO
+func New(client *minio.Client, cfg *config.Config) (*Storage, error) {
+	publicURL, err := url.Parse(strings.TrimSuffix(cfg.MinIO.PublicURL, "/"))
+	if err != nil || publicURL.Host == "" {
+		return nil, fmt.Errorf("invalid MINIO_PUBLIC_URL: %q", cfg.MinIO.PublicURL)
+	}
+
+	// Подпись presigned URL включает хост, поэтому нужен клиент с публичным адресом.
+	// Регион задан явно, чтобы подпись не требовала запроса к серверу.
+	presignClient, err := minio.New(publicURL.Host, &minio.Options{
+		Creds:        credentials.NewStaticV4(cfg.MinIO.AccessKeyID, cfg.MinIO.SecretAccessKey, ""),
+		Secure:       publicURL.Scheme == "https",
+		Region:       "us-east-1",
+		BucketLookup: minio.BucketLookupPath,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create MinIO presign client: %w", err)
+	}
+
+	return &Storage{
+		client:        client,
+		presignClient: presignClient,
+		bucket:        cfg.MinIO.BucketName,
+		publicURL:     publicURL.String(),
+	}, nil
+}
+
+// Префиксы ключей: обработанные картинки и сырые загрузки по presigned POST
+const (
+	ImagesPrefix  = "images/"
+	UploadsPrefix = "uploads/"
+)
+
+// NewObjectKey генерирует ключ объекта вида images/2025/01/<uuid>.jpg
+func NewObjectKey(ext string) string {
+	return newKey(ImagesPrefix, ext)
+}
+
+// NewUploadKey генерирует ключ сырой загрузки вида uploads/2025/01/<uuid>.png.
+// Сырые файлы лежат отдельно, чтобы их нельзя было выдать за обработанную карточку
+func NewUploadKey(ext string) string {
+	return newKey(UploadsPrefix, ext)
+}
+
+func newKey(prefix, ext string) string {
+	now := time.Now()
+	return fmt.Sprintf("%s%04d/%02d/%s%s", prefix, now.Year(), int(now.Month()), uuid.NewString(), ext)
+}
+
+// ImageKeys возвращает ключи карточки и миниатюры для ключа исходного файла:
+// images/.../<uuid>.png -> images/.../<uuid>.jpg, images/.../<uuid>_thumb.jpg
+func ImageKeys(key string) (card, thumbnail string) {
+	base := strings.TrimSuffix(key, path.Ext(key))
+	return base + ".jpg", base + "_thumb.jpg"
+}
+
+// IsImageKey проверяет, что ключ выдан сервером (а не указывает на произвольный объект)
+func IsImageKey(key string) bool {
+	return isKeyIn(ImagesPrefix, key)
+}
+
+// IsUploadKey проверяет, что ключ выдан сервером для сырой загрузки
+func IsUploadKey(key string) bool {
+	return isKeyIn(UploadsPrefix, key)
+}
+
+func isKeyIn(prefix, key string) bool {
+	return strings.HasPrefix(key, prefix) && !strings.Contains(key, "..") && path.Clean(key) == key
+}
+
+// Upload загружает объект в bucket
+func (s *Storage) Upload(ctx context.Context, key string, data io.Reader, size int64, contentType string) error {
+	_, err := s.client.PutObject(ctx, s.bucket, key, data, size, minio.PutObjectOptions{
+		ContentType:  contentType,
+		CacheControl: "public, max-age=31536000, immutable",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload object %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// Download читает объект целиком, но не больше maxSize байт
+func (s *Storage) Download(ctx context.Context, key string, maxSize int64) ([]byte, error) {
+	obj, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object %s: %w", key, err)
+	}
+	defer obj.Close()
+
+	data, err := io.ReadAll(io.LimitReader(obj, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object %s: %w", key, err)
+	}
+	if int64(len(data)) > maxSize {
+		return nil, ErrTooLarge
+	}
+
+	return data, nil
+}
+
+// Remove удаляет объект из bucket
+func (s *Storage) Remove(ctx context.Context, key string) error {
+	if err := s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to remove object %s: %w", key, err)
+	}
+	return nil
+}
+
+// PresignedPost возвращает URL и поля формы для прямой загрузки объекта из браузера.
+// Политика фиксирует ключ и тип и ограничивает размер файла maxSize байтами
+func (s *Storage) PresignedPost(ctx context.Context, key, contentType string, maxSize int64, expiry time.Duration) (string, map[string]string, error) {
+	policy := minio.NewPostPolicy()
+	err := errors.Join(
+		policy.SetBucket(s.bucket),
+		policy.SetKey(key),
+		policy.SetContentType(contentType),
+		policy.SetContentLengthRange(1, maxSize),
+		policy.SetExpires(time.Now().UTC().Add(expiry)),
+	)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to build upload policy for %s: %w", key, err)
+	}
+
+	u, fields, err := s.presignClient.PresignedPostPolicy(ctx, policy)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to presign object %s: %w", key, err)
+	}
+
+	return u.String(), fields, nil
+}
+
+// CardKeyFromURL возвращает ключ карточки по ее публичной ссылке. Ссылки на чужие хосты,
+// исходники и миниатюры не принимаются
+func (s *Storage) CardKeyFromURL(rawURL string) (string, bool) {
+	key, ok := strings.CutPrefix(rawURL, s.publicURL+"/"+s.bucket+"/")
+	if !ok || !IsImageKey(key) {
+		return "", false
+	}
+
+	if card, _ := ImageKeys(key); card != key || strings.HasSuffix(key, "_thumb.jpg") {
+		return "", false
+	}
+
+	return key, true
+}
+
+// PublicURL возвращает ссылку, по которой браузер может прочитать объект
+func (s *Storage) PublicURL(key string) string {
+	return s.publicURL + "/" + path.Join(s.bucket, key)
+}
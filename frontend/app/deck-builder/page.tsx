@@ -16,6 +16,7 @@ import { useAuthStore } from '@/stores/auth'
 interface LocationForm {
   name: string
   image_url: string
+  thumbnail_url?: string // Для превью; сервер выводит ее из image_url сам
   roles: string[]
 }
 
@@ -69,8 +70,8 @@ export default function DeckBuilderPage() {
 
     setUploadingImage(index)
     try {
-      const url = await uploadFile(file)
-      handleLocationChange(index, 'image_url', url)
+      const { url, thumbnail_url } = await uploadFile(file)
+      setLocations(prev => prev.map((loc, i) => (i === index ? { ...loc, image_url: url, thumbnail_url } : loc)))
     } catch (error) {
       console.error('Upload failed:', error)
       alert('Ошибка загрузки изображения')
@@ -121,8 +122,8 @@ export default function DeckBuilderPage() {
         alert('Все локации должны иметь изображение')
         return
       }
-      if (location.roles.length === 0) {
-        alert('Все локации должны иметь хотя бы одну роль')
+      if (location.roles.length < 6 || location.roles.length > 20) {
+        alert('У каждой локации должно быть от 6 до 20 ролей')
         return
       }
     }
@@ -241,7 +242,7 @@ export default function DeckBuilderPage() {
                           {location.image_url && (
                             <div className="flex-1">
                               <img
-                                src={location.image_url}
+                                src={location.thumbnail_url || location.image_url}
                                 alt={location.name || 'Preview'}
                                 className="w-32 h-32 object-cover rounded-lg border border-border"
                                 onError={(e) => {
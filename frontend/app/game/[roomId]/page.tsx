@@ -16,7 +16,7 @@ export default function GamePage() {
   const { user } = useAuthStore()
   const { roomState, myRole, error, isConnected, sendMessage } = useGameWebSocket(roomId)
   const [votingAnswer, setVotingAnswer] = useState<boolean | null>(null)
-  const [spyGuess, setSpyGuess] = useState('')
+  const [spyGuess, setSpyGuess] = useState<number | null>(null)
 
   const players = useMemo(() => {
     if (!roomState) return []
@@ -59,9 +59,9 @@ export default function GamePage() {
   }
 
   const handleSpyGuess = () => {
-    if (!spyGuess.trim()) return
-    sendMessage('spy_guess', { location: spyGuess })
-    setSpyGuess('')
+    if (spyGuess === null) return
+    sendMessage('spy_guess', { location_id: spyGuess })
+    setSpyGuess(null)
   }
 
   const handleKickPlayer = (targetUserId: number) => {
@@ -213,14 +213,28 @@ export default function GamePage() {
                             Ваша задача - угадать локацию или дождаться окончания времени
                           </p>
                           <div className="mt-6 space-y-2">
-                            <input
-                              type="text"
-                              value={spyGuess}
-                              onChange={(e) => setSpyGuess(e.target.value)}
-                              placeholder="Угадайте локацию..."
-                              className="w-full px-4 py-2 rounded-lg bg-background border border-input"
-                            />
-                            <Button onClick={handleSpyGuess} className="w-full hover-lift">
+                            <div className="grid grid-cols-2 gap-2 max-h-64 overflow-y-auto">
+                              {myRole.locations?.map((location) => (
+                                <button
+                                  key={location.id}
+                                  type="button"
+                                  onClick={() => setSpyGuess(location.id)}
+                                  className={`flex items-center gap-2 p-2 rounded-lg border text-left ${
+                                    spyGuess === location.id ? 'border-primary bg-primary/10' : 'border-input'
+                                  }`}
+                                >
+                                  {location.thumbnail_url && (
+                                    <img
+                                      src={location.thumbnail_url}
+                                      alt={location.name}
+                                      className="w-10 h-10 rounded object-cover"
+                                    />
+                                  )}
+                                  <span className="text-sm">{location.name}</span>
+                                </button>
+                              ))}
+                            </div>
+                            <Button onClick={handleSpyGuess} disabled={spyGuess === null} className="w-full hover-lift">
                               Угадать
                             </Button>
                           </div>
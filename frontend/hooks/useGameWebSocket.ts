@@ -38,7 +38,8 @@ export function useGameWebSocket(roomId: string) {
 
     try {
       const wsUrl = getWebSocketURL(roomId)
-      const ws = new WebSocket(wsUrl)
+      // Браузер не позволяет передать заголовок Authorization, поэтому токен идет в подпротоколе
+      const ws = new WebSocket(wsUrl, ['bearer', token])
 
       ws.onopen = () => {
         setIsConnected(true)
@@ -16,15 +16,26 @@ const getAPIBaseURL = (): string => {
 
 const API_BASE_URL = getAPIBaseURL()
 
+// Presigned POST: файл отправляется формой на url с полями fields и полем file,
+// затем ключ передается в /api/upload/presigned/complete за ссылками на карточку
 export interface PresignedURLResponse {
   url: string
+  fields: Record<string, string>
   key: string
+  expires_at: string
+}
+
+export interface UploadResult {
+  url: string // Карточка локации
+  key: string
+  thumbnail_url: string // Миниатюра для списков
+  thumbnail_key: string
 }
 
 /**
  * Загружает файл через multipart/form-data
  */
-export async function uploadFile(file: File): Promise<string> {
+export async function uploadFile(file: File): Promise<UploadResult> {
   const formData = new FormData()
   formData.append('file', file)
 
@@ -48,22 +59,12 @@ export async function uploadFile(file: File): Promise<string> {
     throw new Error(error.error || 'Failed to upload file')
   }
 
-  const data = await response.json()
-  // API возвращает относительный путь, нужно преобразовать в полный URL
-  // В продакшене это будет presigned URL или публичный URL
-  if (data.url.startsWith('/')) {
-    // Для локальной разработки используем MinIO напрямую
-    if (API_BASE_URL && API_BASE_URL.includes('localhost:8080')) {
-      return `${API_BASE_URL.replace(':8080', ':9000')}${data.url}`
-    }
-    // В production через nginx proxy
-    return `http://localhost:9000${data.url}`
-  }
-  return data.url
+  // Бэкенд возвращает полные публичные URL карточки и миниатюры в MinIO
+  return response.json()
 }
 
 /**
- * Получает presigned URL для загрузки файла
+ * Получает presigned POST для загрузки файла
  */
 export async function getPresignedURL(filename: string, contentType: string): Promise<PresignedURLResponse> {
   const response = await api.post<PresignedURLResponse>('/api/upload/presigned', {
@@ -1,6 +1,11 @@
 // Game types
-export type GameStatus = 'waiting' | 'playing' | 'voting' | 'finished'
+export type GameStatus = 'waiting' | 'playing' | 'voting' | 'final_accusation' | 'last_chance' | 'finished'
 export type PlayerRole = 'spy' | 'local'
+export type TimerMode = 'fixed' | 'per_player' | 'untimed'
+export type VoteChoice = 'for' | 'against' | 'abstain'
+export type MissingVotePolicy = 'against' | 'quorum'
+export type TimeoutRule = 'spy_wins' | 'final_accusation'
+export type WinReason = 'timeout' | 'spy_guessed' | 'wrong_accusation' | 'spy_caught' | 'wrong_guess'
 
 export interface Player {
   user_id: number
@@ -12,19 +17,74 @@ export interface Player {
   location_role?: string
   is_ready: boolean
   is_voted?: boolean
-  vote?: boolean
+  vote?: VoteChoice
+  accusations_left?: number
+  eliminated?: boolean
+  is_connected: boolean
+  disconnected_at?: string
 }
 
 export interface LocationInfo {
+  id: number
   name: string
   image_url: string
+  thumbnail_url?: string
   roles: string[]
 }
 
+export interface LocationOption {
+  id: number
+  name: string
+  thumbnail_url?: string
+}
+
 export interface VotingState {
   target_user_id: number
-  votes: Record<number, boolean>
-  started_at: string
+  votes: Record<number, VoteChoice>
+  deadline: number
+  remaining_seconds?: number // Игра на паузе: дедлайн стоит
+}
+
+export interface QuestionTurn {
+  asker_id: number
+  target_id: number
+  asked_at: string
+}
+
+export interface FinalAccusationState {
+  order: number[]
+  current_user_id: number
+  turn_deadline: number
+}
+
+export interface GameOverPayload {
+  winner: 'spy' | 'locals' | ''
+  win_reason?: WinReason
+  reason?: string // Игра прервана
+  winner_ids?: number[]
+  spy_ids: number[]
+  location: LocationInfo
+  round: number
+  rounds: number
+  round_points?: Record<number, number>
+  scores?: Record<number, number>
+  match_over?: boolean
+}
+
+export interface LastChanceState {
+  spy_id: number
+  deadline: number
+}
+
+export interface VoteResultPayload {
+  target_user_id: number
+  for: number
+  against: number
+  abstained: number
+  missing: number
+  policy: MissingVotePolicy
+  timed_out: boolean
+  passed: boolean
 }
 
 export interface RoomState {
@@ -36,12 +96,39 @@ export interface RoomState {
   timer_end?: number
   voting?: VotingState
   winner?: 'spy' | 'locals'
+  rounds: number
+  round: number
+  scores?: Record<number, number>
+  auto_start: boolean
+  allow_duplicate_roles: boolean
   deck_id: number
   deck_name: string
   max_players: number
   spy_count: number
   duration: number
+  timer_mode: TimerMode
+  per_player_seconds: number
+  freeze_on_vote: boolean
+  vote_timeout: number
+  missing_vote_policy: MissingVotePolicy
+  accusations_per_player: number // 0 - без ограничения
+  vote_cooldown: number
+  reveal_spy_partners: boolean
+  eliminate_caught_spies: boolean
+  spy_last_chance: boolean
+  last_chance_seconds: number
+  last_chance?: LastChanceState
+  timeout_rule: TimeoutRule
+  final_turn_seconds: number
+  final_accusation?: FinalAccusationState
+  current_asker_id?: number
+  asked_by_id?: number
+  question_history?: QuestionTurn[]
+  next_vote_at?: number
+  paused: boolean
+  remaining_seconds?: number
   created_by: number
+  host_id: number
   created_at: string
 }
 
@@ -56,8 +143,15 @@ export interface GameStartedPayload {
     role: PlayerRole
     location?: string
     location_role?: string
+    location_image_url?: string
+    locations?: LocationOption[] // Только для шпиона
+    partners?: number[] // Другие шпионы (если reveal_spy_partners)
+    eliminated?: boolean
   }
-  timer_end: number
+  timer_end?: number
+  timer_mode: TimerMode
+  paused?: boolean
+  remaining_seconds?: number
   spy_count: number
 }
 
@@ -75,14 +169,27 @@ export interface VoteStartPayload {
 }
 
 export interface VoteAnswerPayload {
-  vote: boolean // true = за, false = против
+  vote?: boolean // true = за, false = против
+  abstain?: boolean
 }
 
 export interface SpyGuessPayload {
-  location: string
+  location_id: number
+}
+
+export interface PassQuestionPayload {
+  target_user_id: number
+}
+
+export interface RematchPayload {
+  auto_start?: boolean
 }
 
 export interface KickPlayerPayload {
   target_user_id: number
 }
 
+export interface TransferHostPayload {
+  target_user_id: number
+}
+
@@ -32,7 +32,9 @@ export interface Location {
   deck_id: number
   name: string
   image_url: string
+  thumbnail_url?: string
   roles: string[]
+  fallback_role?: string // Роль для местных, которым не хватило ролей
 }
 
 export interface Deck {
@@ -41,6 +43,10 @@ export interface Deck {
   name: string
   is_public: boolean
   status: 'draft' | 'pending' | 'approved' | 'rejected'
+  fallback_role?: string // Запасная роль для локаций без своей
+  rejection_reason?: string
+  reviewed_by_id?: number
+  reviewed_at?: string
   created_at: string
   updated_at: string
   locations?: Location[]
@@ -53,6 +59,8 @@ export interface CreateRoomRequest {
   max_players?: number
   spy_count?: number
   duration?: number
+  timer_mode?: 'fixed' | 'per_player' | 'untimed'
+  per_player_seconds?: number
 }
 
 export interface CreateRoomResponse {